@@ -0,0 +1,93 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package apitest provides a fake GraphQL server of ChaosCenter for the tests
+// of the commands
+package apitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Request is a GraphQL request received by the server
+type Request struct {
+	Query     string                     `json:"query"`
+	Variables map[string]json.RawMessage `json:"variables"`
+}
+
+// Decode unmarshals the variable of the given name into v
+func (r Request) Decode(name string, v interface{}) error {
+	variable, ok := r.Variables[name]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(variable, v)
+}
+
+// Handler returns the data answering a request of an operation
+type Handler func(request Request) (interface{}, error)
+
+// Respond returns a Handler answering every request with data
+func Respond(data interface{}) Handler {
+	return func(Request) (interface{}, error) {
+		return data, nil
+	}
+}
+
+// NewServer returns a server answering the requests of each operation, such
+// as listExperiment, with its handler. Invalid requests, unexpected operations
+// and handler errors fail the test and are answered with a 400.
+func NewServer(t *testing.T, handlers map[string]Handler) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Invalid request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		for operation, handler := range handlers {
+			if !strings.Contains(request.Query, operation+"(") {
+				continue
+			}
+			data, err := handler(request)
+			if err != nil {
+				t.Errorf("Invalid %s request: %v", operation, err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{operation: data},
+			})
+			return
+		}
+
+		t.Errorf("Unexpected query %s", request.Query)
+		http.Error(w, "unexpected query", http.StatusBadRequest)
+	}))
+}
+
+// Responses returns the handlers answering each operation with its data
+func Responses(data map[string]interface{}) map[string]Handler {
+	handlers := map[string]Handler{}
+	for operation, response := range data {
+		handlers[operation] = Respond(response)
+	}
+	return handlers
+}
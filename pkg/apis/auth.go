@@ -16,11 +16,12 @@ limitations under the License.
 package apis
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 
+	"github.com/litmuschaos/litmusctl/pkg/config"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 
 	"github.com/litmuschaos/litmusctl/pkg/types"
@@ -31,7 +32,7 @@ type Payload struct {
 	Password string `json:"password"`
 }
 
-func Auth(input types.AuthInput) (types.AuthResponse, error) {
+func Auth(ctx context.Context, input types.AuthInput) (types.AuthResponse, error) {
 	payloadBytes, err := json.Marshal(Payload{
 		Username: input.Username,
 		Password: input.Password,
@@ -42,10 +43,12 @@ func Auth(input types.AuthInput) (types.AuthResponse, error) {
 	}
 
 	// Sending token as empty because auth server doesn't need Authorization token to validate.
-	resp, err := SendRequest(SendRequestParams{input.Endpoint + utils.AuthAPIPath + "/login", ""}, payloadBytes, string(types.Post))
+	// Logging in again has no side effects, so the request is safe to retry.
+	resp, err := SendRequest(ctx, SendRequestParams{Endpoint: input.Endpoint + utils.AuthAPIPath + "/login", Retryable: true}, payloadBytes, string(types.Post))
 	if err != nil {
 		return types.AuthResponse{}, err
 	}
+	defer resp.Body.Close()
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -61,6 +64,49 @@ func Auth(input types.AuthInput) (types.AuthResponse, error) {
 
 		return authResponse, nil
 	} else {
-		return types.AuthResponse{}, errors.New("Unmatched status code:" + string(bodyBytes))
+		return types.AuthResponse{}, classify(&StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
 	}
 }
+
+// Logout revokes the token of the user, so that it can't be used anymore
+func Logout(ctx context.Context, cred types.Credentials) error {
+	resp, err := SendRequest(ctx, SendRequestParams{
+		Endpoint: cred.Endpoint + utils.AuthAPIPath + "/logout",
+		Token:    "Bearer " + cred.Token,
+	}, nil, string(types.Post))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return classify(&StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
+	}
+
+	return nil
+}
+
+// Status checks that the authentication server behind the endpoint is up. It
+// sends a single request through the given transport, without retries.
+func Status(ctx context.Context, endpoint string, transport http.RoundTripper) error {
+	client := &http.Client{Timeout: config.RequestTimeout, Transport: transport}
+	resp, err := sendOnce(ctx, client, SendRequestParams{Endpoint: endpoint + utils.AuthAPIPath + "/status"}, nil, string(types.Get))
+	if err != nil {
+		return classify(err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return classify(&StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
+	}
+
+	return nil
+}
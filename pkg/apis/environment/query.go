@@ -34,6 +34,8 @@ const (
 						environments {
 							environmentID
 							name
+							description
+							tags
 							createdAt
 							updatedAt
 							createdBy{
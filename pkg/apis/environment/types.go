@@ -1,6 +1,9 @@
 package environment
 
-import model "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+import (
+	model "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+)
 
 type CreateEnvironmentGQLRequest struct {
 	Query     string `json:"query"`
@@ -11,11 +14,8 @@ type CreateEnvironmentGQLRequest struct {
 }
 
 type CreateEnvironmentResponse struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data CreateEnvironmentData `json:"data"`
+	Errors apis.GraphQLErrors    `json:"errors"`
+	Data   CreateEnvironmentData `json:"data"`
 }
 
 type CreateEnvironmentData struct {
@@ -23,11 +23,8 @@ type CreateEnvironmentData struct {
 }
 
 type GetEnvironmentData struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data GetEnvironment `json:"data"`
+	Errors apis.GraphQLErrors `json:"errors"`
+	Data   GetEnvironment     `json:"data"`
 }
 
 type GetEnvironment struct {
@@ -43,11 +40,8 @@ type CreateEnvironmentGetGQLRequest struct {
 }
 
 type ListEnvironmentData struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data EnvironmentsList `json:"data"`
+	Errors apis.GraphQLErrors `json:"errors"`
+	Data   EnvironmentsList   `json:"data"`
 }
 
 type EnvironmentsList struct {
@@ -71,11 +65,8 @@ type CreateEnvironmentDeleteGQLRequest struct {
 }
 
 type DeleteChaosEnvironmentData struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data DeleteChaosEnvironmentDetails `json:"data"`
+	Errors apis.GraphQLErrors            `json:"errors"`
+	Data   DeleteChaosEnvironmentDetails `json:"data"`
 }
 
 type DeleteChaosEnvironmentDetails struct {
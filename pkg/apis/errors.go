@@ -0,0 +1,150 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Kinds of failure reported by ChaosCenter. Use errors.Is to check which kind
+// an error returned by this package belongs to.
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrServer           = errors.New("server error")
+	ErrNetwork          = errors.New("network error")
+)
+
+// Exit codes returned by litmusctl for each kind of failure, so that scripts
+// can branch on them. Any other failure exits with ExitCodeError, see
+// utils.ExitCode.
+const (
+	ExitCodeError            = 1
+	ExitCodeUnauthenticated  = 3
+	ExitCodePermissionDenied = 4
+	ExitCodeNotFound         = 5
+	ExitCodeAlreadyExists    = 6
+	ExitCodeServer           = 7
+	ExitCodeNetwork          = 8
+)
+
+var exitCodes = map[error]int{
+	ErrUnauthenticated:  ExitCodeUnauthenticated,
+	ErrPermissionDenied: ExitCodePermissionDenied,
+	ErrNotFound:         ExitCodeNotFound,
+	ErrAlreadyExists:    ExitCodeAlreadyExists,
+	ErrServer:           ExitCodeServer,
+	ErrNetwork:          ExitCodeNetwork,
+}
+
+// Error is a classified failure. It matches its Kind and the underlying error
+// with errors.Is and errors.As, so GraphQLErrors and *StatusError stay reachable.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// ExitCode returns the process exit code for the kind of the error
+func (e *Error) ExitCode() int {
+	return exitCodes[e.Kind]
+}
+
+// serverMessages maps the messages ChaosCenter uses in GraphQL errors to a kind
+var serverMessages = []struct {
+	substr string
+	kind   error
+}{
+	{substr: "no documents in result", kind: ErrNotFound},
+	{substr: "permission_denied", kind: ErrPermissionDenied},
+	{substr: "multiple write errors", kind: ErrAlreadyExists},
+	{substr: "duplicate key", kind: ErrAlreadyExists},
+	{substr: "multiple run errors", kind: ErrAlreadyExists},
+	{substr: "invalid token", kind: ErrUnauthenticated},
+	{substr: "token is expired", kind: ErrUnauthenticated},
+	{substr: "unauthorized", kind: ErrUnauthenticated},
+}
+
+// classify wraps err into an *Error when its kind can be recognised. Cancellation
+// and unrecognised errors are returned unchanged.
+func classify(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return err
+	}
+
+	if kind := kindOf(err); kind != nil {
+		return &Error{Kind: kind, Err: err}
+	}
+	return err
+}
+
+func kindOf(err error) error {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch code := statusErr.StatusCode; {
+		case code == http.StatusUnauthorized:
+			return ErrUnauthenticated
+		case code == http.StatusForbidden:
+			return ErrPermissionDenied
+		case code == http.StatusNotFound:
+			return ErrNotFound
+		case code == http.StatusConflict:
+			return ErrAlreadyExists
+		case code >= http.StatusInternalServerError || code == http.StatusTooManyRequests:
+			return ErrServer
+		}
+		return nil
+	}
+
+	var gqlErrs GraphQLErrors
+	if errors.As(err, &gqlErrs) {
+		for _, gqlErr := range gqlErrs {
+			message := strings.ToLower(gqlErr.Message)
+			for _, m := range serverMessages {
+				if strings.Contains(message, m.substr) {
+					return m.kind
+				}
+			}
+		}
+		return nil
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrNetwork
+	}
+	return nil
+}
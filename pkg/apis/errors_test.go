@@ -0,0 +1,122 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+)
+
+func TestQueryClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantKind error
+		wantCode int
+	}{
+		{
+			name:     "missing document",
+			status:   http.StatusOK,
+			body:     `{"errors":[{"message":"mongo: no documents in result"}]}`,
+			wantKind: ErrNotFound,
+			wantCode: ExitCodeNotFound,
+		},
+		{
+			name:     "permission denied",
+			status:   http.StatusOK,
+			body:     `{"errors":[{"message":"permission_denied"}]}`,
+			wantKind: ErrPermissionDenied,
+			wantCode: ExitCodePermissionDenied,
+		},
+		{
+			name:     "duplicate experiment",
+			status:   http.StatusOK,
+			body:     `{"errors":[{"message":"multiple write errors: [{write errors: [{E11000 duplicate key error}]}]"}]}`,
+			wantKind: ErrAlreadyExists,
+			wantCode: ExitCodeAlreadyExists,
+		},
+		{
+			name:     "invalid token",
+			status:   http.StatusOK,
+			body:     `{"errors":[{"message":"Invalid Token"}]}`,
+			wantKind: ErrUnauthenticated,
+			wantCode: ExitCodeUnauthenticated,
+		},
+		{
+			name:     "unauthorized status",
+			status:   http.StatusUnauthorized,
+			body:     `unauthorized`,
+			wantKind: ErrUnauthenticated,
+			wantCode: ExitCodeUnauthenticated,
+		},
+		{
+			name:     "internal server error",
+			status:   http.StatusInternalServerError,
+			body:     `internal error`,
+			wantKind: ErrServer,
+			wantCode: ExitCodeServer,
+		},
+		{
+			name:     "unknown graphql error",
+			status:   http.StatusOK,
+			body:     `{"errors":[{"message":"something else"}]}`,
+			wantCode: ExitCodeError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := Query[testData](context.Background(), GraphQLClient{Endpoint: server.URL}, "mutation test { name }", struct{}{})
+			if err == nil {
+				t.Fatal("Query() expected an error")
+			}
+			if tt.wantKind != nil && !errors.Is(err, tt.wantKind) {
+				t.Errorf("Query() error = %v, want kind %v", err, tt.wantKind)
+			}
+			if code := utils.ExitCode(err); code != tt.wantCode {
+				t.Errorf("ExitCode() = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSendRequestNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	endpoint := server.URL
+	server.Close()
+
+	_, err := SendRequest(context.Background(), SendRequestParams{Endpoint: endpoint}, nil, string(types.Post))
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("SendRequest() error = %v, want kind %v", err, ErrNetwork)
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.ExitCode() != ExitCodeNetwork {
+		t.Errorf("SendRequest() error = %v, want exit code %d", err, ExitCodeNetwork)
+	}
+}
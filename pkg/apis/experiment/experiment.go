@@ -16,10 +16,9 @@ limitations under the License.
 package experiment
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"io"
-	"net/http"
+	"strconv"
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmusctl/pkg/apis"
@@ -28,88 +27,19 @@ import (
 )
 
 // CreateExperiment sends GraphQL API request for creating a Experiment
-func CreateExperiment(pid string, requestData model.SaveChaosExperimentRequest, cred types.Credentials) (RunExperimentResponse, error) {
+func CreateExperiment(ctx context.Context, pid string, requestData model.SaveChaosExperimentRequest, cred types.Credentials) (RunExperimentResponse, error) {
 
 	// Query to Save the Experiment
-	var gqlReq SaveChaosExperimentGraphQLRequest
-
-	gqlReq.Query = SaveExperimentQuery
-	gqlReq.Variables.ProjectID = pid
-	gqlReq.Variables.SaveChaosExperimentRequest = requestData
-
-	query, err := json.Marshal(gqlReq)
-	if err != nil {
-		return RunExperimentResponse{}, err
-	}
-
-	resp, err := apis.SendRequest(
-		apis.SendRequestParams{
-			Endpoint: cred.ServerEndpoint + utils.GQLAPIPath,
-			Token:    cred.Token,
-		},
-		query,
-		string(types.Post),
-	)
+	_, err := SaveExperiment(ctx, pid, requestData, cred)
 	if err != nil {
 		return RunExperimentResponse{}, err
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-
-	defer resp.Body.Close()
-	if err != nil {
-		return RunExperimentResponse{}, errors.New("Error in saving Chaos Experiment: " + err.Error())
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		var savedExperiment SaveExperimentData
-
-		err = json.Unmarshal(bodyBytes, &savedExperiment)
-
-		if err != nil {
-			return RunExperimentResponse{}, errors.New("Error in saving Chaos Experiment: " + err.Error())
-		}
-
-		// Errors present
-		if len(savedExperiment.Errors) > 0 {
-			return RunExperimentResponse{}, errors.New(savedExperiment.Errors[0].Message)
-		}
-
-	} else {
-		return RunExperimentResponse{}, errors.New("error in saving Chaos Experiment")
-	}
-
 	// Query to Run the Chaos Experiment
-	runQuery := `{"query":"mutation{ \n runChaosExperiment(experimentID:  \"` + requestData.ID + `\", projectID:  \"` + pid + `\"){\n notifyID \n}}"}`
-	resp, err = apis.SendRequest(apis.SendRequestParams{Endpoint: cred.ServerEndpoint + utils.GQLAPIPath, Token: cred.Token}, []byte(runQuery), string(types.Post))
-
-	if err != nil {
-		return RunExperimentResponse{}, errors.New("Error in Running Chaos Experiment: " + err.Error())
-	}
-
-	bodyBytes, err = io.ReadAll(resp.Body)
-	defer resp.Body.Close()
-	if err != nil {
-		return RunExperimentResponse{}, errors.New("Error in Running Chaos Experiment: " + err.Error())
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		var runExperiment RunExperimentResponse
-		err = json.Unmarshal(bodyBytes, &runExperiment)
-		if err != nil {
-			return RunExperimentResponse{}, errors.New("Error in Running Chaos Experiment: " + err.Error())
-		}
-
-		if len(runExperiment.Errors) > 0 {
-			return RunExperimentResponse{}, errors.New(runExperiment.Errors[0].Message)
-		}
-		return runExperiment, nil
-	} else {
-		return RunExperimentResponse{}, err
-	}
+	return RunExperiment(ctx, pid, requestData.ID, cred)
 }
 
-func SaveExperiment(pid string, requestData model.SaveChaosExperimentRequest, cred types.Credentials) (SaveExperimentData, error) {
+func SaveExperiment(ctx context.Context, pid string, requestData model.SaveChaosExperimentRequest, cred types.Credentials) (SaveExperimentData, error) {
 
 	// Query to Save the Experiment
 	var gqlReq SaveChaosExperimentGraphQLRequest
@@ -118,231 +48,175 @@ func SaveExperiment(pid string, requestData model.SaveChaosExperimentRequest, cr
 	gqlReq.Variables.ProjectID = pid
 	gqlReq.Variables.SaveChaosExperimentRequest = requestData
 
-	query, err := json.Marshal(gqlReq)
+	savedExperiment, err := apis.Query[SavedExperimentDetails](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
 		return SaveExperimentData{}, err
 	}
 
-	resp, err := apis.SendRequest(
-		apis.SendRequestParams{
-			Endpoint: cred.ServerEndpoint + utils.GQLAPIPath,
-			Token:    cred.Token,
-		},
-		query,
-		string(types.Post),
-	)
-	if err != nil {
-		return SaveExperimentData{}, err
-	}
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-
-	defer resp.Body.Close()
-	if err != nil {
-		return SaveExperimentData{}, errors.New("Error in saving Chaos Experiment: " + err.Error())
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		var savedExperiment SaveExperimentData
-
-		err = json.Unmarshal(bodyBytes, &savedExperiment)
-
-		if err != nil {
-			return SaveExperimentData{}, errors.New("Error in saving Chaos Experiment: " + err.Error())
-		}
-
-		// Errors present
-		if len(savedExperiment.Errors) > 0 {
-			return SaveExperimentData{}, errors.New(savedExperiment.Errors[0].Message)
-		}
-		return savedExperiment, nil
-
-	} else {
-		return SaveExperimentData{}, errors.New("error in saving Chaos Experiment")
-	}
-
+	return SaveExperimentData{Data: savedExperiment}, nil
 }
 
-func RunExperiment(pid string, eid string, cred types.Credentials) (RunExperimentResponse, error) {
-	var err error
-	runQuery := `{"query":"mutation{ \n runChaosExperiment(experimentID:  \"` + eid + `\", projectID:  \"` + pid + `\"){\n notifyID \n}}"}`
-
-	resp, err := apis.SendRequest(apis.SendRequestParams{Endpoint: cred.ServerEndpoint + utils.GQLAPIPath, Token: cred.Token}, []byte(runQuery), string(types.Post))
+func RunExperiment(ctx context.Context, pid string, eid string, cred types.Credentials) (RunExperimentResponse, error) {
+	var gqlReq RunChaosExperimentGraphQLRequest
 
-	if err != nil {
-		return RunExperimentResponse{}, errors.New("Error in Running Chaos Experiment: " + err.Error())
-	}
+	gqlReq.Query = RunExperimentQuery
+	gqlReq.Variables.ProjectID = pid
+	gqlReq.Variables.ExperimentID = eid
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
+	runExperiment, err := apis.Query[RunExperimentData](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
-		return RunExperimentResponse{}, errors.New("Error in Running Chaos Experiment: " + err.Error())
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		var runExperiment RunExperimentResponse
-		err = json.Unmarshal(bodyBytes, &runExperiment)
-		if err != nil {
-			return RunExperimentResponse{}, errors.New("Error in Running Chaos Experiment: " + err.Error())
-		}
-
-		if len(runExperiment.Errors) > 0 {
-			return RunExperimentResponse{}, errors.New(runExperiment.Errors[0].Message)
-		}
-		return runExperiment, nil
-	} else {
 		return RunExperimentResponse{}, err
 	}
+
+	return RunExperimentResponse{Data: runExperiment}, nil
 }
 
 // GetExperimentList sends GraphQL API request for fetching a list of experiments.
-func GetExperimentList(pid string, in model.ListExperimentRequest, cred types.Credentials) (ExperimentListData, error) {
+func GetExperimentList(ctx context.Context, pid string, in model.ListExperimentRequest, cred types.Credentials) (ExperimentListData, error) {
 
 	var gqlReq GetChaosExperimentsGraphQLRequest
-	var err error
 
 	gqlReq.Query = ListExperimentQuery
 	gqlReq.Variables.GetChaosExperimentRequest = in
 	gqlReq.Variables.ProjectID = pid
 
-	query, err := json.Marshal(gqlReq)
+	experimentList, err := apis.Query[ExperimentList](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
 		return ExperimentListData{}, err
 	}
 
-	resp, err := apis.SendRequest(
-		apis.SendRequestParams{
-			Endpoint: cred.ServerEndpoint + utils.GQLAPIPath,
-			Token:    cred.Token,
-		},
-		query,
-		string(types.Post),
-	)
-	if err != nil {
-		return ExperimentListData{}, err
+	return ExperimentListData{Data: experimentList}, nil
+}
+
+// FindExperiment returns the Chaos Experiment of the project with the given
+// name, or generated from generateName when name is empty. It returns nil
+// when there is no such experiment.
+func FindExperiment(ctx context.Context, pid string, name string, generateName string, cred types.Credentials) (*model.Experiment, error) {
+	filterName := name
+	if filterName == "" {
+		filterName = generateName
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
+	experiments, err := GetExperimentList(ctx, pid, model.ListExperimentRequest{
+		Filter: &model.ExperimentFilterInput{ExperimentName: &filterName},
+	}, cred)
 	if err != nil {
-		return ExperimentListData{}, err
+		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		var experimentList ExperimentListData
-		err = json.Unmarshal(bodyBytes, &experimentList)
-		if err != nil {
-			return ExperimentListData{}, err
+	var matches []*model.Experiment
+	for _, e := range experiments.Data.ListExperimentDetails.Experiments {
+		if (name != "" && e.Name == name) || (name == "" && utils.IsGeneratedName(e.Name, generateName)) {
+			matches = append(matches, e)
 		}
+	}
 
-		if len(experimentList.Errors) > 0 {
-			return ExperimentListData{}, errors.New(experimentList.Errors[0].Message)
-		}
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, errors.New(strconv.Itoa(len(matches)) + " Chaos Experiments were generated from " + generateName + ", set metadata.name to pick one")
+	}
+}
+
+// GetExperimentRun sends GraphQL API request for fetching an experiment run,
+// along with its manifest and execution data
+func GetExperimentRun(ctx context.Context, pid string, experimentRunID string, cred types.Credentials) (GetExperimentRunData, error) {
+	var gqlReq GetExperimentRunGraphQLRequest
+
+	gqlReq.Query = GetExperimentRunQuery
+	gqlReq.Variables.ProjectID = pid
+	gqlReq.Variables.ExperimentRunID = &experimentRunID
 
-		return experimentList, nil
-	} else {
-		return ExperimentListData{}, errors.New("Error while fetching the Chaos Experiments")
+	experimentRun, err := apis.Query[GetExperimentRunDetails](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
+	if err != nil {
+		return GetExperimentRunData{}, err
 	}
+
+	return GetExperimentRunData{Data: experimentRun}, nil
 }
 
 // GetExperimentRunsList sends GraphQL API request for fetching a list of experiment runs.
-func GetExperimentRunsList(pid string, in model.ListExperimentRunRequest, cred types.Credentials) (ExperimentRunListData, error) {
+func GetExperimentRunsList(ctx context.Context, pid string, in model.ListExperimentRunRequest, cred types.Credentials) (ExperimentRunListData, error) {
 
 	var gqlReq GetChaosExperimentRunGraphQLRequest
-	var err error
 
 	gqlReq.Query = ListExperimentRunsQuery
 	gqlReq.Variables.ProjectID = pid
 	gqlReq.Variables.GetChaosExperimentRunRequest = in
 
-	query, err := json.Marshal(gqlReq)
+	experimentRunList, err := apis.Query[ExperimentRunsList](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
 		return ExperimentRunListData{}, err
 	}
 
-	resp, err := apis.SendRequest(
-		apis.SendRequestParams{
-			Endpoint: cred.ServerEndpoint + utils.GQLAPIPath,
-			Token:    cred.Token,
-		},
-		query,
-		string(types.Post),
-	)
-	if err != nil {
-		return ExperimentRunListData{}, err
-	}
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
-	if err != nil {
-		return ExperimentRunListData{}, err
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		var experimentRunList ExperimentRunListData
-		err = json.Unmarshal(bodyBytes, &experimentRunList)
-		if err != nil {
-			return ExperimentRunListData{}, err
-		}
-
-		if len(experimentRunList.Errors) > 0 {
-			return ExperimentRunListData{}, errors.New(experimentRunList.Errors[0].Message)
-		}
-
-		return experimentRunList, nil
-	} else {
-		return ExperimentRunListData{}, errors.New("Error while fetching the Chaos Experiment runs")
-	}
+	return ExperimentRunListData{Data: experimentRunList}, nil
 }
 
 // DeleteChaosExperiment sends GraphQL API request for deleting a given Chaos Experiment.
-func DeleteChaosExperiment(projectID string, experimentID *string, cred types.Credentials) (DeleteChaosExperimentData, error) {
+func DeleteChaosExperiment(ctx context.Context, projectID string, experimentID *string, cred types.Credentials) (DeleteChaosExperimentData, error) {
 
 	var gqlReq DeleteChaosExperimentGraphQLRequest
-	var err error
 
 	gqlReq.Query = DeleteExperimentQuery
 	gqlReq.Variables.ProjectID = projectID
 	gqlReq.Variables.ExperimentID = experimentID
-	//var experiment_run_id string = ""
-	//gqlReq.Variables.ExperimentRunID = &experiment_run_id
 
-	query, err := json.Marshal(gqlReq)
+	deletedExperiment, err := apis.Query[DeleteChaosExperimentDetails](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
 		return DeleteChaosExperimentData{}, err
 	}
 
-	resp, err := apis.SendRequest(
-		apis.SendRequestParams{
-			Endpoint: cred.ServerEndpoint + utils.GQLAPIPath,
-			Token:    cred.Token,
-		},
-		query,
-		string(types.Post),
-	)
+	return DeleteChaosExperimentData{Data: deletedExperiment}, nil
+}
+
+// StopExperimentRuns sends GraphQL API request for stopping the given run of
+// an experiment, or all of its runs when experimentRunID is nil
+func StopExperimentRuns(ctx context.Context, projectID string, experimentID string, experimentRunID *string, cred types.Credentials) (StopExperimentRunsData, error) {
+
+	var gqlReq StopExperimentRunsGraphQLRequest
+
+	gqlReq.Query = StopExperimentRunsQuery
+	gqlReq.Variables.ProjectID = projectID
+	gqlReq.Variables.ExperimentID = experimentID
+	gqlReq.Variables.ExperimentRunID = experimentRunID
+
+	stopped, err := apis.Query[StopExperimentRunsDetails](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
-		return DeleteChaosExperimentData{}, err
+		return StopExperimentRunsData{}, err
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
+	return StopExperimentRunsData{Data: stopped}, nil
+}
+
+// UpdateCronExperimentState sends GraphQL API request for suspending or
+// resuming the schedule of a cron experiment
+func UpdateCronExperimentState(ctx context.Context, projectID string, experimentID string, disable bool, cred types.Credentials) (UpdateCronExperimentStateData, error) {
+
+	var gqlReq UpdateCronExperimentStateGraphQLRequest
+
+	gqlReq.Query = UpdateCronExperimentStateQuery
+	gqlReq.Variables.ProjectID = projectID
+	gqlReq.Variables.ExperimentID = experimentID
+	gqlReq.Variables.Disable = disable
+
+	updated, err := apis.Query[UpdateCronExperimentStateDetails](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
-		return DeleteChaosExperimentData{}, err
+		return UpdateCronExperimentStateData{}, err
 	}
 
-	if resp.StatusCode == http.StatusOK {
-		var deletedExperiment DeleteChaosExperimentData
-		err = json.Unmarshal(bodyBytes, &deletedExperiment)
-		if err != nil {
-			return DeleteChaosExperimentData{}, err
-		}
-
-		if len(deletedExperiment.Errors) > 0 {
-			return DeleteChaosExperimentData{}, errors.New(deletedExperiment.Errors[0].Message)
-		}
+	return UpdateCronExperimentStateData{Data: updated}, nil
+}
 
-		return deletedExperiment, nil
-	} else {
-		return DeleteChaosExperimentData{}, errors.New("Error while deleting the Chaos Experiment")
+// IsTerminalPhase reports whether a run in the given phase is finished, so
+// that it won't inject chaos anymore
+func IsTerminalPhase(phase model.ExperimentRunStatus) bool {
+	switch phase {
+	case model.ExperimentRunStatusCompleted, model.ExperimentRunStatusCompletedWithError, model.ExperimentRunStatusStopped,
+		model.ExperimentRunStatusSkipped, model.ExperimentRunStatusError, model.ExperimentRunStatusTimeout:
+		return true
 	}
+	return false
 }
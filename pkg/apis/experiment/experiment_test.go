@@ -0,0 +1,107 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+type experimentRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID    string `json:"projectID"`
+		ExperimentID string `json:"experimentID"`
+		Request      struct {
+			ID string `json:"id"`
+		} `json:"request"`
+	} `json:"variables"`
+}
+
+func TestCreateExperimentUsesVariables(t *testing.T) {
+	ids := []string{
+		`plain-id`,
+		`id"with"quotes`,
+		`id\with\backslashes\`,
+		`"){ deleteChaosExperiment(projectID: "x", experimentID: "y") }#`,
+	}
+
+	for _, id := range ids {
+		t.Run(id, func(t *testing.T) {
+			var requests []experimentRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req experimentRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("Failed to decode request: %v", err)
+				}
+				requests = append(requests, req)
+				if strings.HasPrefix(req.Query, "mutation saveChaosExperiment") {
+					w.Write([]byte(`{"data":{"saveChaosExperiment":"saved"}}`))
+					return
+				}
+				w.Write([]byte(`{"data":{"runChaosExperiment":{"notifyID":"notify"}}}`))
+			}))
+			defer server.Close()
+
+			cred := types.Credentials{ServerEndpoint: server.URL}
+			resp, err := CreateExperiment(context.Background(), id, model.SaveChaosExperimentRequest{ID: id}, cred)
+			if err != nil {
+				t.Fatalf("CreateExperiment() unexpected error = %v", err)
+			}
+			if resp.Data.RunExperimentDetails.NotifyID != "notify" {
+				t.Errorf("CreateExperiment() notifyID = %q, want %q", resp.Data.RunExperimentDetails.NotifyID, "notify")
+			}
+
+			if len(requests) != 2 {
+				t.Fatalf("Server received %d requests, want 2", len(requests))
+			}
+			save, run := requests[0], requests[1]
+			if save.Query != SaveExperimentQuery || save.Variables.ProjectID != id || save.Variables.Request.ID != id {
+				t.Errorf("Unexpected save request: %+v", save)
+			}
+			if run.Query != RunExperimentQuery {
+				t.Errorf("Run request query = %q, want %q", run.Query, RunExperimentQuery)
+			}
+			if run.Variables.ProjectID != id || run.Variables.ExperimentID != id {
+				t.Errorf("Run request variables = %+v, want both IDs to be %q", run.Variables, id)
+			}
+		})
+	}
+}
+
+func TestIsTerminalPhase(t *testing.T) {
+	tests := map[model.ExperimentRunStatus]bool{
+		model.ExperimentRunStatusNa:                 false,
+		model.ExperimentRunStatusRunning:            false,
+		model.ExperimentRunStatusCompleted:          true,
+		model.ExperimentRunStatusCompletedWithError: true,
+		model.ExperimentRunStatusStopped:            true,
+		model.ExperimentRunStatusError:              true,
+		model.ExperimentRunStatusTimeout:            true,
+	}
+	for phase, want := range tests {
+		if got := IsTerminalPhase(phase); got != want {
+			t.Errorf("IsTerminalPhase(%s) = %v, want %v", phase, got, want)
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package experiment
 
 const (
+	RunExperimentQuery = `mutation runChaosExperiment($projectID: ID!, $experimentID: String!) {
+                      runChaosExperiment(projectID: $projectID, experimentID: $experimentID) {
+                        notifyID
+                      }
+                    }`
+
 	SaveExperimentQuery = `mutation saveChaosExperiment($projectID: ID!, $request: SaveChaosExperimentRequest!) {
                       saveChaosExperiment(projectID: $projectID, request: $request)
                      }`
@@ -13,6 +19,8 @@ const (
                           experimentManifest
                           cronSyntax
                           name
+                          description
+                          tags
                           infra {
                             name
                             infraID
@@ -41,9 +49,33 @@ const (
                           }
                           phase
                           resiliencyScore
+                          faultsPassed
+                          faultsFailed
+                          faultsAwaited
+                          totalFaults
+                          notifyID
                         }
                       }
                     }`
+	GetExperimentRunQuery = `query getExperimentRun($projectID: ID!, $experimentRunID: ID, $notifyID: ID) {
+                      getExperimentRun(projectID: $projectID, experimentRunID: $experimentRunID, notifyID: $notifyID) {
+                        experimentRunID
+                        experimentID
+                        experimentName
+                        experimentManifest
+                        executionData
+                        phase
+                        notifyID
+                        infra {
+                          infraID
+                          name
+                          infraNamespace
+                        }
+                      }
+                    }`
+	StopExperimentRunsQuery = `mutation stopExperimentRuns($projectID: ID!, $experimentID: String!, $experimentRunID: String) {
+                      stopExperimentRuns(projectID: $projectID, experimentID: $experimentID, experimentRunID: $experimentRunID)
+                    }`
 	DeleteExperimentQuery = `mutation deleteChaosExperiment($projectID: ID!, $experimentID: String!, $experimentRunID: String) {
                       deleteChaosExperiment(
                         projectID: $projectID
@@ -51,4 +83,7 @@ const (
                         experimentRunID: $experimentRunID
                       )
                     }`
+	UpdateCronExperimentStateQuery = `mutation updateCronExperimentState($experimentID: String!, $disable: Boolean!, $projectID: ID!) {
+                      updateCronExperimentState(experimentID: $experimentID, disable: $disable, projectID: $projectID)
+                    }`
 )
@@ -1,13 +1,13 @@
 package experiment
 
-import "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+import (
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+)
 
 type SaveExperimentData struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data SavedExperimentDetails `json:"data"`
+	Errors apis.GraphQLErrors     `json:"errors"`
+	Data   SavedExperimentDetails `json:"data"`
 }
 
 type SavedExperimentDetails struct {
@@ -22,12 +22,17 @@ type SaveChaosExperimentGraphQLRequest struct {
 	} `json:"variables"`
 }
 
+type RunChaosExperimentGraphQLRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID    string `json:"projectID"`
+		ExperimentID string `json:"experimentID"`
+	} `json:"variables"`
+}
+
 type RunExperimentResponse struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data RunExperimentData `json:"data"`
+	Errors apis.GraphQLErrors `json:"errors"`
+	Data   RunExperimentData  `json:"data"`
 }
 
 type RunExperimentData struct {
@@ -35,11 +40,8 @@ type RunExperimentData struct {
 }
 
 type ExperimentListData struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data ExperimentList `json:"data"`
+	Errors apis.GraphQLErrors `json:"errors"`
+	Data   ExperimentList     `json:"data"`
 }
 
 type ExperimentList struct {
@@ -55,17 +57,32 @@ type GetChaosExperimentsGraphQLRequest struct {
 }
 
 type ExperimentRunListData struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data ExperimentRunsList `json:"data"`
+	Errors apis.GraphQLErrors `json:"errors"`
+	Data   ExperimentRunsList `json:"data"`
 }
 
 type ExperimentRunsList struct {
 	ListExperimentRunDetails model.ListExperimentRunResponse `json:"listExperimentRun"`
 }
 
+type GetExperimentRunData struct {
+	Errors apis.GraphQLErrors      `json:"errors"`
+	Data   GetExperimentRunDetails `json:"data"`
+}
+
+type GetExperimentRunDetails struct {
+	ExperimentRun model.ExperimentRun `json:"getExperimentRun"`
+}
+
+type GetExperimentRunGraphQLRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID       string  `json:"projectID"`
+		ExperimentRunID *string `json:"experimentRunID"`
+		NotifyID        *string `json:"notifyID"`
+	} `json:"variables"`
+}
+
 type GetChaosExperimentRunGraphQLRequest struct {
 	Query     string `json:"query"`
 	Variables struct {
@@ -75,11 +92,8 @@ type GetChaosExperimentRunGraphQLRequest struct {
 }
 
 type DeleteChaosExperimentData struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data DeleteChaosExperimentDetails `json:"data"`
+	Errors apis.GraphQLErrors           `json:"errors"`
+	Data   DeleteChaosExperimentDetails `json:"data"`
 }
 
 type DeleteChaosExperimentDetails struct {
@@ -94,3 +108,39 @@ type DeleteChaosExperimentGraphQLRequest struct {
 		ExperimentRunID *string `json:"experimentRunID"`
 	} `json:"variables"`
 }
+
+type StopExperimentRunsData struct {
+	Errors apis.GraphQLErrors        `json:"errors"`
+	Data   StopExperimentRunsDetails `json:"data"`
+}
+
+type StopExperimentRunsDetails struct {
+	IsStopped bool `json:"stopExperimentRuns"`
+}
+
+type StopExperimentRunsGraphQLRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID       string  `json:"projectID"`
+		ExperimentID    string  `json:"experimentID"`
+		ExperimentRunID *string `json:"experimentRunID"`
+	} `json:"variables"`
+}
+
+type UpdateCronExperimentStateData struct {
+	Errors apis.GraphQLErrors               `json:"errors"`
+	Data   UpdateCronExperimentStateDetails `json:"data"`
+}
+
+type UpdateCronExperimentStateDetails struct {
+	IsUpdated bool `json:"updateCronExperimentState"`
+}
+
+type UpdateCronExperimentStateGraphQLRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID    string `json:"projectID"`
+		ExperimentID string `json:"experimentID"`
+		Disable      bool   `json:"disable"`
+	} `json:"variables"`
+}
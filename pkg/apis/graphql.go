@@ -0,0 +1,135 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+)
+
+// GraphQLError is a single entry of the errors array returned by the GraphQL server
+type GraphQLError struct {
+	Message string   `json:"message"`
+	Path    []string `json:"path"`
+}
+
+// GraphQLErrors holds every error returned by the GraphQL server for a request
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, gqlErr := range e {
+		messages = append(messages, gqlErr.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// StatusError is returned when the server responds with a non 200 status code
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Unmatched status code: %d %s", e.StatusCode, e.Body)
+}
+
+// GraphQLRequest is the payload sent to the GraphQL server
+type GraphQLRequest[V any] struct {
+	Query     string `json:"query"`
+	Variables V      `json:"variables"`
+}
+
+// GraphQLResponse is the envelope of every GraphQL server response
+type GraphQLResponse[T any] struct {
+	Data   T             `json:"data"`
+	Errors GraphQLErrors `json:"errors"`
+}
+
+// GraphQLClient sends queries and mutations to the ChaosCenter GraphQL server
+type GraphQLClient struct {
+	Endpoint string
+	Token    string
+}
+
+// NewGraphQLClient returns a client for the GraphQL server of the given account
+func NewGraphQLClient(cred types.Credentials) GraphQLClient {
+	return GraphQLClient{
+		Endpoint: cred.ServerEndpoint + utils.GQLAPIPath,
+		Token:    cred.Token,
+	}
+}
+
+// Query sends the query along with its variables and decodes the data field of
+// the response into T. If the server reports any errors, the decoded data is
+// returned together with a GraphQLErrors value carrying all of them. Failures
+// of a known kind are wrapped in an *Error, see classify.
+func Query[T any, V any](ctx context.Context, c GraphQLClient, query string, variables V) (T, error) {
+	var result GraphQLResponse[T]
+
+	payload, err := json.Marshal(GraphQLRequest[V]{
+		Query:     query,
+		Variables: variables,
+	})
+	if err != nil {
+		return result.Data, err
+	}
+
+	params := SendRequestParams{
+		Endpoint:  c.Endpoint,
+		Token:     c.Token,
+		Retryable: !isMutation(query) || config.RetryMutations,
+	}
+	resp, err := SendRequest(ctx, params, payload, string(types.Post))
+	if err != nil {
+		return result.Data, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result.Data, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result.Data, classify(&StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
+	}
+
+	err = json.Unmarshal(bodyBytes, &result)
+	if err != nil {
+		return result.Data, err
+	}
+
+	if len(result.Errors) > 0 {
+		return result.Data, classify(result.Errors)
+	}
+
+	return result.Data, nil
+}
+
+// isMutation reports whether the GraphQL document is a mutation, which is not
+// safe to retry unless the user opted in with --retry-mutations
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testVariables struct {
+	ProjectID string `json:"projectID"`
+}
+
+type testData struct {
+	Name string `json:"name"`
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantName   string
+		wantErrs   int
+		wantStatus bool
+	}{
+		{
+			name:     "successful response",
+			status:   http.StatusOK,
+			body:     `{"data":{"name":"litmus"}}`,
+			wantName: "litmus",
+		},
+		{
+			name:     "all graphql errors are returned",
+			status:   http.StatusOK,
+			body:     `{"data":null,"errors":[{"message":"first"},{"message":"second","path":["listExperiment"]}]}`,
+			wantErrs: 2,
+		},
+		{
+			name:       "non 200 status code",
+			status:     http.StatusBadGateway,
+			body:       `bad gateway`,
+			wantStatus: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotReq GraphQLRequest[testVariables]
+			var gotToken string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotToken = r.Header.Get("Authorization")
+				if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+					t.Errorf("Failed to decode request: %v", err)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := GraphQLClient{Endpoint: server.URL, Token: "token"}
+			got, err := Query[testData](context.Background(), client, "query test { name }", testVariables{ProjectID: "pid"})
+
+			if gotToken != "token" {
+				t.Errorf("Authorization header = %q, want %q", gotToken, "token")
+			}
+			if gotReq.Query != "query test { name }" || gotReq.Variables.ProjectID != "pid" {
+				t.Errorf("Unexpected request payload: %+v", gotReq)
+			}
+
+			var gqlErrs GraphQLErrors
+			var statusErr *StatusError
+			switch {
+			case tt.wantErrs > 0:
+				if !errors.As(err, &gqlErrs) || len(gqlErrs) != tt.wantErrs {
+					t.Fatalf("Query() error = %v, want %d graphql errors", err, tt.wantErrs)
+				}
+				if err.Error() != "first; second" {
+					t.Errorf("Query() error message = %q, want %q", err.Error(), "first; second")
+				}
+			case tt.wantStatus:
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+					t.Fatalf("Query() error = %v, want status error %d", err, tt.status)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("Query() unexpected error = %v", err)
+				}
+				if got.Name != tt.wantName {
+					t.Errorf("Query() name = %q, want %q", got.Name, tt.wantName)
+				}
+			}
+		})
+	}
+}
+
+func TestIsMutation(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "query listExperiment($projectID: ID!) { listExperiment(projectID: $projectID) }", want: false},
+		{query: "\n\t mutation runChaosExperiment { runChaosExperiment }", want: true},
+		{query: "{ getServerVersion { key value } }", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isMutation(tt.query); got != tt.want {
+			t.Errorf("isMutation(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
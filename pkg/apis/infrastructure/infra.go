@@ -16,11 +16,8 @@ limitations under the License.
 package infrastructure
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/types"
@@ -30,47 +27,22 @@ import (
 )
 
 // GetInfraList lists the Chaos Infrastructure connected to the specified project
-func GetInfraList(c types.Credentials, pid string, request models.ListInfraRequest) (InfraData, error) {
+func GetInfraList(ctx context.Context, c types.Credentials, pid string, request models.ListInfraRequest) (InfraData, error) {
 	var gplReq ListInfraGraphQLRequest
 	gplReq.Query = ListInfraQuery
 	gplReq.Variables.ProjectID = pid
 	gplReq.Variables.ListInfraRequest = request
 
-	query, err := json.Marshal(gplReq)
-	if err != nil {
-		return InfraData{}, err
-	}
-	resp, err := apis.SendRequest(apis.SendRequestParams{Endpoint: c.ServerEndpoint + utils.GQLAPIPath, Token: c.Token}, query, string(types.Post))
+	Infra, err := apis.Query[InfraList](ctx, apis.NewGraphQLClient(c), gplReq.Query, gplReq.Variables)
 	if err != nil {
 		return InfraData{}, err
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
-	if err != nil {
-
-		return InfraData{}, err
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		var Infra InfraData
-		err = json.Unmarshal(bodyBytes, &Infra)
-		if err != nil {
-			return InfraData{}, err
-		}
-
-		if len(Infra.Errors) > 0 {
-			return InfraData{}, errors.New(Infra.Errors[0].Message)
-		}
-
-		return Infra, nil
-	} else {
-		return InfraData{}, fmt.Errorf("error getting detais from server")
-	}
+	return InfraData{Data: Infra}, nil
 }
 
 // ConnectInfra connects the  Infra with the given details
-func ConnectInfra(infra types.Infra, cred types.Credentials) (InfraConnectionData, error) {
+func ConnectInfra(ctx context.Context, infra types.Infra, cred types.Credentials) (InfraConnectionData, error) {
 	var gqlReq RegisterInfraGqlRequest
 	gqlReq.Query = RegisterInfraQuery
 	gqlReq.Variables.ProjectId = infra.ProjectId
@@ -96,36 +68,12 @@ func ConnectInfra(infra types.Infra, cred types.Credentials) (InfraConnectionDat
 		gqlReq.Variables.RegisterInfraRequest.Tolerations = toleration
 	}
 
-	query, err := json.Marshal(gqlReq)
+	connectInfra, err := apis.Query[RegisterInfra](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
-		return InfraConnectionData{}, errors.New("Error in registering Chaos Infrastructure: " + err.Error())
-	}
-
-	resp, err := apis.SendRequest(apis.SendRequestParams{Endpoint: cred.ServerEndpoint + utils.GQLAPIPath, Token: cred.Token}, query, string(types.Post))
-	if err != nil {
-		return InfraConnectionData{}, errors.New("Error in registering Chaos Infrastructure: " + err.Error())
-	}
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
-	if err != nil {
-		return InfraConnectionData{}, errors.New("Error in registering Chaos Infrastructure: " + err.Error())
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		var connectInfra InfraConnectionData
-		err = json.Unmarshal(bodyBytes, &connectInfra)
-		if err != nil {
-			return InfraConnectionData{}, errors.New("Error in registering Chaos Infrastructure: " + err.Error())
-		}
-
-		if len(connectInfra.Errors) > 0 {
-			return InfraConnectionData{}, errors.New(connectInfra.Errors[0].Message)
-		}
-		return connectInfra, nil
-	} else {
 		return InfraConnectionData{}, err
 	}
+
+	return InfraConnectionData{Data: connectInfra}, nil
 }
 
 func CreateRegisterInfraRequest(infra types.Infra) (request models.RegisterInfraRequest) {
@@ -145,90 +93,30 @@ func CreateRegisterInfraRequest(infra types.Infra) (request models.RegisterInfra
 }
 
 // DisconnectInfra sends GraphQL API request for disconnecting Chaos Infra(s).
-func DisconnectInfra(projectID string, infraID string, cred types.Credentials) (DisconnectInfraData, error) {
+func DisconnectInfra(ctx context.Context, projectID string, infraID string, cred types.Credentials) (DisconnectInfraData, error) {
 
 	var gqlReq DisconnectInfraGraphQLRequest
-	var err error
 
 	gqlReq.Query = DisconnectInfraQuery
 	gqlReq.Variables.ProjectID = projectID
 	gqlReq.Variables.InfraID = infraID
 
-	query, err := json.Marshal(gqlReq)
-	if err != nil {
-		return DisconnectInfraData{}, err
-	}
-
-	resp, err := apis.SendRequest(
-		apis.SendRequestParams{
-			Endpoint: cred.ServerEndpoint + utils.GQLAPIPath,
-			Token:    cred.Token,
-		},
-		query,
-		string(types.Post),
-	)
+	disconnectInfraData, err := apis.Query[DisconnectInfraDetails](ctx, apis.NewGraphQLClient(cred), gqlReq.Query, gqlReq.Variables)
 	if err != nil {
 		return DisconnectInfraData{}, err
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
-	if err != nil {
-		return DisconnectInfraData{}, err
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		var disconnectInfraData DisconnectInfraData
-		err = json.Unmarshal(bodyBytes, &disconnectInfraData)
-		if err != nil {
-			return DisconnectInfraData{}, err
-		}
-
-		if len(disconnectInfraData.Errors) > 0 {
-			return DisconnectInfraData{}, errors.New(disconnectInfraData.Errors[0].Message)
-		}
-
-		return disconnectInfraData, nil
-	} else {
-		return DisconnectInfraData{}, err
-	}
+	return DisconnectInfraData{Data: disconnectInfraData}, nil
 }
 
-func GetServerVersion(endpoint string) (ServerVersionResponse, error) {
+func GetServerVersion(ctx context.Context, endpoint string) (ServerVersionResponse, error) {
 	var gqlReq ServerVersionRequest
-	var err error
-
 	gqlReq.Query = ServerVersionQuery
-	query, err := json.Marshal(gqlReq)
-	if err != nil {
-		return ServerVersionResponse{}, err
-	}
-	resp, err := apis.SendRequest(
-		apis.SendRequestParams{
-			Endpoint: endpoint + utils.GQLAPIPath,
-		},
-		query,
-		string(types.Post),
-	)
-	if err != nil {
-		return ServerVersionResponse{}, err
-	}
-	bodyBytes, err := io.ReadAll(resp.Body)
-	defer resp.Body.Close()
+
+	version, err := apis.Query[ServerVersionData](ctx, apis.GraphQLClient{Endpoint: endpoint + utils.GQLAPIPath}, gqlReq.Query, struct{}{})
 	if err != nil {
 		return ServerVersionResponse{}, err
 	}
-	if resp.StatusCode == http.StatusOK {
-		var version ServerVersionResponse
-		err = json.Unmarshal(bodyBytes, &version)
-		if err != nil {
-			return ServerVersionResponse{}, err
-		}
-		if len(version.Errors) > 0 {
-			return ServerVersionResponse{}, errors.New(version.Errors[0].Message)
-		}
-		return version, nil
-	} else {
-		return ServerVersionResponse{}, errors.New(resp.Status)
-	}
+
+	return ServerVersionResponse{Data: version}, nil
 }
@@ -26,8 +26,14 @@ const (
 						infras {
 							infraID
 							name
+							description
+							tags
 							isActive
 							environmentID
+							platformName
+							infraNamespace
+							serviceAccount
+							infraScope
 						}
 					}
 					}`
@@ -1,13 +1,13 @@
 package infrastructure
 
-import models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+import (
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+)
 
 type InfraData struct {
-	Data   InfraList `json:"data"`
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
+	Data   InfraList          `json:"data"`
+	Errors apis.GraphQLErrors `json:"errors"`
 }
 
 type InfraList struct {
@@ -36,11 +36,8 @@ type RegisterInfraGqlRequest struct {
 }
 
 type InfraConnectionData struct {
-	Data   RegisterInfra `json:"data"`
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
+	Data   RegisterInfra      `json:"data"`
+	Errors apis.GraphQLErrors `json:"errors"`
 }
 
 type RegisterInfra struct {
@@ -48,11 +45,8 @@ type RegisterInfra struct {
 }
 
 type DisconnectInfraData struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data DisconnectInfraDetails `json:"data"`
+	Errors apis.GraphQLErrors     `json:"errors"`
+	Data   DisconnectInfraDetails `json:"data"`
 }
 
 type DisconnectInfraDetails struct {
@@ -72,11 +66,8 @@ type ServerVersionRequest struct {
 }
 
 type ServerVersionResponse struct {
-	Data   ServerVersionData `json:"data"`
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
+	Data   ServerVersionData  `json:"data"`
+	Errors apis.GraphQLErrors `json:"errors"`
 }
 
 type ServerVersionData struct {
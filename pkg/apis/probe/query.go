@@ -27,6 +27,21 @@ const (
 			initialDelay
 			evaluationTimeout
 			stopOnFailure
+			url
+			method {
+			  get {
+				criteria
+				responseCode
+			  }
+			  post {
+				contentType
+				body
+				bodyPath
+				criteria
+				responseCode
+			  }
+			}
+			insecureSkipVerify
 		  }
 		  kubernetesCMDProperties{
 			probeTimeout
@@ -37,6 +52,13 @@ const (
 			initialDelay
 			evaluationTimeout
 			stopOnFailure
+			command
+			comparator {
+			  type
+			  value
+			  criteria
+			}
+			source
 		  }
 		  k8sProperties {
 			probeTimeout
@@ -47,6 +69,14 @@ const (
 			initialDelay
 			evaluationTimeout
 			stopOnFailure
+			group
+			version
+			resource
+			namespace
+			resourceNames
+			fieldSelector
+			labelSelector
+			operation
 		  }
 		  promProperties {
 			probeTimeout
@@ -57,6 +87,14 @@ const (
 			initialDelay
 			evaluationTimeout
 			stopOnFailure
+			endpoint
+			query
+			queryPath
+			comparator {
+			  type
+			  value
+			  criteria
+			}
 		  }
 		  createdAt
 		  createdBy{
@@ -75,6 +113,13 @@ const (
 	  }
 	`
 
+	AddProbeQuery = `mutation addProbe($request: ProbeRequest!, $projectID: ID!) {
+		addProbe(request: $request, projectID: $projectID) {
+		  name
+		}
+	  }
+	`
+
 	DeleteProbeQuery = `mutation deleteProbe($probeName: ID!, $projectID: ID!) {
 		deleteProbe(probeName: $probeName, projectID: $projectID)
 	  }
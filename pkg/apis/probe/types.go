@@ -1,6 +1,9 @@
 package probe
 
-import model "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+import (
+	model "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+)
 
 type GetProbeGQLRequest struct {
 	Query     string `json:"query"`
@@ -11,11 +14,8 @@ type GetProbeGQLRequest struct {
 }
 
 type GetProbeResponse struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data GetProbeResponseData `json:"data"`
+	Errors apis.GraphQLErrors   `json:"errors"`
+	Data   GetProbeResponseData `json:"data"`
 }
 
 type GetProbeResponseData struct {
@@ -31,11 +31,8 @@ type ListProbeGQLRequest struct {
 }
 
 type ListProbeResponse struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data ListProbeResponseData `json:"data"`
+	Errors apis.GraphQLErrors    `json:"errors"`
+	Data   ListProbeResponseData `json:"data"`
 }
 
 type ListProbeResponseData struct {
@@ -50,11 +47,8 @@ type DeleteProbeGQLRequest struct {
 }
 
 type DeleteProbeResponse struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data DeleteProbeResponseData `json:"data"`
+	Errors apis.GraphQLErrors      `json:"errors"`
+	Data   DeleteProbeResponseData `json:"data"`
 }
 
 type DeleteProbeResponseData struct {
@@ -70,13 +64,27 @@ type GetProbeYAMLGQLRequest struct {
 }
 
 type GetProbeYAMLResponse struct {
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-	Data GetProbeYAMLResponseData `json:"data"`
+	Errors apis.GraphQLErrors       `json:"errors"`
+	Data   GetProbeYAMLResponseData `json:"data"`
 }
 
 type GetProbeYAMLResponseData struct {
 	GetProbeYAML string `json:"getProbeYAML"`
 }
+
+type AddProbeGQLRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID string             `json:"projectID"`
+		Request   model.ProbeRequest `json:"request"`
+	} `json:"variables"`
+}
+
+type AddProbeResponse struct {
+	Errors apis.GraphQLErrors   `json:"errors"`
+	Data   AddProbeResponseData `json:"data"`
+}
+
+type AddProbeResponseData struct {
+	AddProbe model.Probe `json:"addProbe"`
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package apis
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -43,7 +44,7 @@ type createProjectPayload struct {
 	ProjectName string `json:"projectName"`
 }
 
-func CreateProjectRequest(projectName string, cred types.Credentials) (CreateProjectResponse, error) {
+func CreateProjectRequest(ctx context.Context, projectName string, cred types.Credentials) (CreateProjectResponse, error) {
 	payloadBytes, err := json.Marshal(createProjectPayload{
 		ProjectName: projectName,
 	})
@@ -51,7 +52,7 @@ func CreateProjectRequest(projectName string, cred types.Credentials) (CreatePro
 	if err != nil {
 		return CreateProjectResponse{}, err
 	}
-	resp, err := SendRequest(SendRequestParams{cred.Endpoint + utils.AuthAPIPath + "/create_project", "Bearer " + cred.Token}, payloadBytes, string(types.Post))
+	resp, err := SendRequest(ctx, SendRequestParams{Endpoint: cred.Endpoint + utils.AuthAPIPath + "/create_project", Token: "Bearer " + cred.Token}, payloadBytes, string(types.Post))
 	if err != nil {
 		return CreateProjectResponse{}, err
 	}
@@ -77,7 +78,7 @@ func CreateProjectRequest(projectName string, cred types.Credentials) (CreatePro
 		utils.White_B.Println("project/" + project.Data.Name + " created")
 		return project, nil
 	} else {
-		return CreateProjectResponse{}, errors.New("Unmatched status code:" + string(bodyBytes))
+		return CreateProjectResponse{}, classify(&StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
 	}
 }
 
@@ -97,9 +98,9 @@ type listProjectResponse struct {
 	} `json:"errors"`
 }
 
-func ListProject(cred types.Credentials) (listProjectResponse, error) {
+func ListProject(ctx context.Context, cred types.Credentials) (listProjectResponse, error) {
 
-	resp, err := SendRequest(SendRequestParams{Endpoint: cred.Endpoint + utils.AuthAPIPath + "/list_projects", Token: "Bearer " + cred.Token}, []byte{}, string(types.Get))
+	resp, err := SendRequest(ctx, SendRequestParams{Endpoint: cred.Endpoint + utils.AuthAPIPath + "/list_projects", Token: "Bearer " + cred.Token}, []byte{}, string(types.Get))
 	if err != nil {
 		return listProjectResponse{}, err
 	}
@@ -124,7 +125,7 @@ func ListProject(cred types.Credentials) (listProjectResponse, error) {
 
 		return data, nil
 	} else {
-		return listProjectResponse{}, errors.New("Unmatched status code:" + string(bodyBytes))
+		return listProjectResponse{}, classify(&StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
 	}
 }
 
@@ -155,13 +156,13 @@ type Project struct {
 }
 
 // GetProjectDetails fetches details of the input user
-func GetProjectDetails(c types.Credentials) (ProjectDetails, error) {
+func GetProjectDetails(ctx context.Context, c types.Credentials) (ProjectDetails, error) {
 	token, _ := jwt.Parse(c.Token, nil)
 	if token == nil {
 		return ProjectDetails{}, nil
 	}
 	Username, _ := token.Claims.(jwt.MapClaims)["username"].(string)
-	resp, err := SendRequest(SendRequestParams{Endpoint: c.Endpoint + utils.AuthAPIPath + "/get_user_with_project/" + Username, Token: "Bearer " + c.Token}, []byte{}, string(types.Get))
+	resp, err := SendRequest(ctx, SendRequestParams{Endpoint: c.Endpoint + utils.AuthAPIPath + "/get_user_with_project/" + Username, Token: "Bearer " + c.Token}, []byte{}, string(types.Get))
 	if err != nil {
 		return ProjectDetails{}, err
 	}
@@ -184,6 +185,6 @@ func GetProjectDetails(c types.Credentials) (ProjectDetails, error) {
 
 		return project, nil
 	} else {
-		return ProjectDetails{}, errors.New("Unmatched status code:" + string(bodyBytes))
+		return ProjectDetails{}, classify(&StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
 	}
 }
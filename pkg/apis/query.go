@@ -0,0 +1,14 @@
+package apis
+
+const (
+	GetInfraDetailsQuery = `query getInfraDetails($projectID: ID!, $infraID: ID!) {
+                      getInfraDetails(projectID: $projectID, infraID: $infraID) {
+                        infraNamespace
+                        infraID
+                      }
+                    }`
+
+	GetInfraManifestQuery = `query getInfraManifest($projectID: ID!, $infraID: ID!, $upgrade: Boolean!) {
+                      getInfraManifest(projectID: $projectID, infraID: $infraID, upgrade: $upgrade)
+                    }`
+)
@@ -17,16 +17,65 @@ package apis
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
 	"net/http"
+	"time"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
 )
 
+// retryBaseWait is the wait before the first retry, it doubles on every attempt
+const retryBaseWait = 500 * time.Millisecond
+
 type SendRequestParams struct {
 	Endpoint string
 	Token    string
+	// Retryable marks a non GET request as safe to send more than once
+	Retryable bool
+}
+
+// SendRequest sends the payload to the given endpoint. The request is aborted
+// when ctx is cancelled or when it takes longer than config.RequestTimeout.
+// GET requests and requests marked as Retryable are retried up to
+// config.RetryCount times on connection errors and 429/502/503/504 responses.
+// Connection errors are returned wrapped with ErrNetwork.
+func SendRequest(ctx context.Context, params SendRequestParams, payload []byte, method string) (*http.Response, error) {
+	retries := 0
+	if method == string(types.Get) || params.Retryable {
+		retries = config.RetryCount
+	}
+
+	transport, err := config.Transport()
+	if err != nil {
+		return &http.Response{}, err
+	}
+	client := &http.Client{Timeout: config.RequestTimeout, Transport: transport}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := sendOnce(ctx, client, params, payload, method)
+		if attempt >= retries || !isTransient(ctx, resp, err) {
+			return resp, classify(err)
+		}
+
+		if err == nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return &http.Response{}, classify(ctx.Err())
+		case <-time.After(backoff(attempt)):
+		}
+	}
 }
 
-func SendRequest(params SendRequestParams, payload []byte, method string) (*http.Response, error) {
-	req, err := http.NewRequest(method, params.Endpoint, bytes.NewBuffer(payload))
+func sendOnce(ctx context.Context, client *http.Client, params SendRequestParams, payload []byte, method string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, params.Endpoint, bytes.NewReader(payload))
 	if err != nil {
 		return &http.Response{}, err
 	}
@@ -34,10 +83,40 @@ func SendRequest(params SendRequestParams, payload []byte, method string) (*http
 	req.Header.Set("Authorization", params.Token)
 	req.Header.Set("Referer", params.Endpoint)
 
-	resp, err := http.DefaultClient.Do(req)
+	start := time.Now()
+	resp, err := client.Do(req)
+	if traceLevel > 0 {
+		traceRequest(req, payload, resp, err, time.Since(start))
+	}
 	if err != nil {
 		return &http.Response{}, err
 	}
 
 	return resp, nil
 }
+
+// isTransient reports whether a failed attempt is worth retrying
+func isTransient(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the jittered wait before the given retry attempt, capped at config.RetryMaxWait
+func backoff(attempt int) time.Duration {
+	wait := config.RetryMaxWait
+	if attempt < 16 && retryBaseWait<<attempt < wait {
+		wait = retryBaseWait << attempt
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	return wait/2 + rand.N(wait/2+1)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+func TestSendRequestAborts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			cancel()
+		}()
+
+		_, err := SendRequest(ctx, SendRequestParams{Endpoint: server.URL}, nil, string(types.Get))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("SendRequest() error = %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("request timeout", func(t *testing.T) {
+		config.RequestTimeout = 50 * time.Millisecond
+		defer func() { config.RequestTimeout = 0 }()
+
+		start := time.Now()
+		_, err := SendRequest(context.Background(), SendRequestParams{Endpoint: server.URL}, nil, string(types.Get))
+		if err == nil {
+			t.Fatal("SendRequest() expected a timeout error")
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("SendRequest() took %v, want it to stop after the timeout", elapsed)
+		}
+	})
+}
+
+func TestSendRequestRetries(t *testing.T) {
+	config.RetryMaxWait = time.Millisecond
+	defer func() { config.RetryMaxWait = 10 * time.Second }()
+
+	tests := []struct {
+		name         string
+		method       string
+		retryable    bool
+		failures     int
+		wantAttempts int
+		wantStatus   int
+	}{
+		{
+			name:         "get recovers after transient failures",
+			method:       string(types.Get),
+			failures:     2,
+			wantAttempts: 3,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "get gives up after the retry count",
+			method:       string(types.Get),
+			failures:     10,
+			wantAttempts: config.RetryCount + 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:         "post is not retried by default",
+			method:       string(types.Post),
+			failures:     2,
+			wantAttempts: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:         "retryable post is retried",
+			method:       string(types.Post),
+			retryable:    true,
+			failures:     1,
+			wantAttempts: 2,
+			wantStatus:   http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts <= tt.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			resp, err := SendRequest(context.Background(), SendRequestParams{Endpoint: server.URL, Retryable: tt.retryable}, []byte(`{}`), tt.method)
+			if err != nil {
+				t.Fatalf("SendRequest() unexpected error = %v", err)
+			}
+			resp.Body.Close()
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("SendRequest() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("SendRequest() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+// ErrSessionExpired is returned when the token of a user has expired and it
+// can't be renewed without asking for the password
+var ErrSessionExpired = errors.New("session expired, run `litmusctl config set-account` to log in again")
+
+// Reauthenticate renews the token of the user through its credential source.
+// The returned user carries the new token and expiry.
+func Reauthenticate(ctx context.Context, endpoint string, user types.User) (types.User, error) {
+	source := user.CredentialSource
+	if source == nil {
+		return user, &Error{Kind: ErrUnauthenticated, Err: ErrSessionExpired}
+	}
+
+	if source.APITokenFile != "" {
+		token, err := readSecretFile(source.APITokenFile)
+		if err != nil {
+			return user, err
+		}
+		expiresIn, err := TokenExpiry(token)
+		if err != nil {
+			return user, err
+		}
+		if !expiresIn.After(time.Now()) {
+			return user, &Error{Kind: ErrUnauthenticated, Err: ErrSessionExpired}
+		}
+
+		user.Token = token
+		user.ExpiresIn = fmt.Sprint(expiresIn.Unix())
+		return user, nil
+	}
+
+	password, err := SourcePassword(*source)
+	if err != nil {
+		return user, err
+	}
+	if password == "" {
+		return user, &Error{Kind: ErrUnauthenticated, Err: ErrSessionExpired}
+	}
+
+	return Login(ctx, types.AuthInput{Endpoint: endpoint, Username: user.Username, Password: password}, user)
+}
+
+// SourcePassword reads the password from the environment variable or file of
+// the credential source. It returns an empty password if the source has neither.
+func SourcePassword(source types.CredentialSource) (string, error) {
+	switch {
+	case source.PasswordEnv != "":
+		password := os.Getenv(source.PasswordEnv)
+		if password == "" {
+			return "", errors.New("environment variable " + source.PasswordEnv + " holding the password is not set")
+		}
+		return password, nil
+	case source.PasswordFile != "":
+		return readSecretFile(source.PasswordFile)
+	}
+
+	return "", nil
+}
+
+// Login authenticates with the password and returns the user with the new
+// token and its expiry
+func Login(ctx context.Context, input types.AuthInput, user types.User) (types.User, error) {
+	resp, err := Auth(ctx, input)
+	if err != nil {
+		return user, err
+	}
+
+	user.Token = resp.AccessToken
+	user.ExpiresIn = fmt.Sprint(time.Now().Add(time.Second * time.Duration(resp.ExpiresIn)).Unix())
+	return user, nil
+}
+
+// TokenExpiry returns the expiry stored in the exp claim of the token
+func TokenExpiry(token string) (time.Time, error) {
+	parsed, _ := jwt.Parse(token, nil)
+	if parsed == nil {
+		return time.Time{}, errors.New("token is not a valid JWT")
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok {
+		return time.Time{}, errors.New("token is not a valid JWT")
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}, errors.New("token has no expiry")
+	}
+
+	return time.Unix(int64(exp), 0), nil
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+func TestReauthenticate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload Payload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if payload.Username != "admin" || payload.Password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"accessToken":"renewed","expiresIn":3600,"type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	passwordFile := filepath.Join(tmpDir, "password")
+	if err := os.WriteFile(passwordFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatalf("Failed to write password file: %v", err)
+	}
+
+	expiry := time.Now().Add(24 * time.Hour).Unix()
+	apiToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": expiry}).SignedString([]byte("key"))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	apiTokenFile := filepath.Join(tmpDir, "token")
+	if err := os.WriteFile(apiTokenFile, []byte(apiToken), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	t.Setenv("LITMUS_TEST_PASSWORD", "secret")
+
+	tests := []struct {
+		name        string
+		source      *types.CredentialSource
+		wantToken   string
+		wantExpired bool
+	}{
+		{
+			name:      "password from environment variable",
+			source:    &types.CredentialSource{PasswordEnv: "LITMUS_TEST_PASSWORD"},
+			wantToken: "renewed",
+		},
+		{
+			name:      "password from file",
+			source:    &types.CredentialSource{PasswordFile: passwordFile},
+			wantToken: "renewed",
+		},
+		{
+			name:      "api token from file",
+			source:    &types.CredentialSource{APITokenFile: apiTokenFile},
+			wantToken: apiToken,
+		},
+		{
+			name:        "no credential source",
+			wantExpired: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := types.User{Username: "admin", Token: "expired", ExpiresIn: "1", CredentialSource: tt.source}
+
+			got, err := Reauthenticate(context.Background(), server.URL, user)
+			if tt.wantExpired {
+				if !errors.Is(err, ErrSessionExpired) || !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("Reauthenticate() error = %v, want %v", err, ErrSessionExpired)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Reauthenticate() unexpected error = %v", err)
+			}
+			if got.Token != tt.wantToken {
+				t.Errorf("Reauthenticate() token = %q, want %q", got.Token, tt.wantToken)
+			}
+			if got.ExpiresIn == user.ExpiresIn {
+				t.Errorf("Reauthenticate() expiry was not renewed")
+			}
+			if tt.source.APITokenFile != "" && got.ExpiresIn != fmt.Sprint(expiry) {
+				t.Errorf("Reauthenticate() expiry = %q, want %d", got.ExpiresIn, expiry)
+			}
+		})
+	}
+}
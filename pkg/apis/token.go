@@ -0,0 +1,193 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+)
+
+// APIToken is a long lived token of a user, managed by the auth server
+type APIToken struct {
+	UserID    string `json:"userID"`
+	Name      string `json:"name"`
+	Token     string `json:"token"`
+	ExpiresAt int64  `json:"expiresAt"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+type createAPITokenPayload struct {
+	UserID              string `json:"userID"`
+	Name                string `json:"name"`
+	DaysUntilExpiration int    `json:"daysUntilExpiration"`
+}
+
+type createAPITokenResponse struct {
+	AccessToken string `json:"accessToken"`
+	Type        string `json:"type"`
+}
+
+type listAPITokensResponse struct {
+	APITokens []APIToken `json:"apiTokens"`
+}
+
+type revokeAPITokenPayload struct {
+	UserID string `json:"userID"`
+	Token  string `json:"token"`
+}
+
+// TokenClaims returns the user ID and username carried by a ChaosCenter token
+func TokenClaims(token string) (string, string, error) {
+	parsed, _ := jwt.Parse(token, nil)
+	if parsed == nil {
+		return "", "", errors.New("token is not a valid JWT")
+	}
+
+	claims, _ := parsed.Claims.(jwt.MapClaims)
+	uid, _ := claims["uid"].(string)
+	username, _ := claims["username"].(string)
+	if uid == "" || username == "" {
+		return "", "", errors.New("token is not a ChaosCenter token, it has no uid or username")
+	}
+
+	return uid, username, nil
+}
+
+// TokenLogin validates an API token against the auth server and returns the
+// user it belongs to
+func TokenLogin(ctx context.Context, endpoint string, token string) (types.User, error) {
+	uid, username, err := TokenClaims(token)
+	if err != nil {
+		return types.User{}, err
+	}
+	expiresAt, err := TokenExpiry(token)
+	if err != nil {
+		return types.User{}, err
+	}
+
+	_, err = authRequest[json.RawMessage](ctx, SendRequestParams{
+		Endpoint: endpoint + utils.AuthAPIPath + "/get_user/" + uid,
+		Token:    "Bearer " + token,
+	}, nil, string(types.Get))
+	if err != nil {
+		return types.User{}, err
+	}
+
+	return types.User{
+		Username:  username,
+		Token:     token,
+		ExpiresIn: fmt.Sprint(expiresAt.Unix()),
+	}, nil
+}
+
+// CreateAPIToken creates an API token for the current user, valid for the given number of days
+func CreateAPIToken(ctx context.Context, cred types.Credentials, name string, days int) (string, error) {
+	uid, _, err := TokenClaims(cred.Token)
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(createAPITokenPayload{
+		UserID:              uid,
+		Name:                name,
+		DaysUntilExpiration: days,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := authRequest[createAPITokenResponse](ctx, SendRequestParams{
+		Endpoint: cred.Endpoint + utils.AuthAPIPath + "/create_token",
+		Token:    "Bearer " + cred.Token,
+	}, payload, string(types.Post))
+	if err != nil {
+		return "", err
+	}
+
+	return resp.AccessToken, nil
+}
+
+// ListAPITokens returns the API tokens of the current user
+func ListAPITokens(ctx context.Context, cred types.Credentials) ([]APIToken, error) {
+	uid, _, err := TokenClaims(cred.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := authRequest[listAPITokensResponse](ctx, SendRequestParams{
+		Endpoint: cred.Endpoint + utils.AuthAPIPath + "/token/" + uid,
+		Token:    "Bearer " + cred.Token,
+	}, nil, string(types.Get))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.APITokens, nil
+}
+
+// RevokeAPIToken revokes an API token of the current user
+func RevokeAPIToken(ctx context.Context, cred types.Credentials, token string) error {
+	uid, _, err := TokenClaims(cred.Token)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(revokeAPITokenPayload{
+		UserID: uid,
+		Token:  token,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = authRequest[json.RawMessage](ctx, SendRequestParams{
+		Endpoint: cred.Endpoint + utils.AuthAPIPath + "/remove_token",
+		Token:    "Bearer " + cred.Token,
+	}, payload, string(types.Post))
+	return err
+}
+
+// authRequest sends a request to the auth server and decodes the response into T
+func authRequest[T any](ctx context.Context, params SendRequestParams, payload []byte, method string) (T, error) {
+	var result T
+
+	resp, err := SendRequest(ctx, params, payload, method)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, classify(&StatusError{StatusCode: resp.StatusCode, Body: string(bodyBytes)})
+	}
+
+	err = json.Unmarshal(bodyBytes, &result)
+	return result, err
+}
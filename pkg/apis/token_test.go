@@ -0,0 +1,199 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+func signTestToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("key"))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestAPITokens(t *testing.T) {
+	expiry := time.Now().Add(24 * time.Hour).Unix()
+	userToken := signTestToken(t, jwt.MapClaims{"uid": "uid-1", "username": "admin", "exp": expiry})
+	revoked := ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/get_user/uid-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+userToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"username":"admin","isInitialLogin":false}`))
+	})
+	mux.HandleFunc("/auth/create_token", func(w http.ResponseWriter, r *http.Request) {
+		var payload createAPITokenPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if payload.UserID != "uid-1" || payload.Name != "ci" || payload.DaysUntilExpiration != 7 {
+			t.Errorf("Unexpected create_token payload: %+v", payload)
+		}
+		w.Write([]byte(`{"accessToken":"new-api-token","type":"Bearer"}`))
+	})
+	mux.HandleFunc("/auth/token/uid-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"apiTokens":[{"userID":"uid-1","name":"ci","token":"new-api-token","expiresAt":1,"createdAt":1}]}`))
+	})
+	mux.HandleFunc("/auth/remove_token", func(w http.ResponseWriter, r *http.Request) {
+		var payload revokeAPITokenPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		revoked = payload.Token
+		w.Write([]byte(`{"message":"api token deleted successfully"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("login with a valid token", func(t *testing.T) {
+		user, err := TokenLogin(context.Background(), server.URL, userToken)
+		if err != nil {
+			t.Fatalf("TokenLogin() unexpected error = %v", err)
+		}
+		if user.Username != "admin" || user.Token != userToken {
+			t.Errorf("TokenLogin() user = %+v", user)
+		}
+		if user.ExpiresIn != fmt.Sprint(expiry) {
+			t.Errorf("TokenLogin() expiry = %q, want %d", user.ExpiresIn, expiry)
+		}
+	})
+
+	t.Run("login with a rejected token", func(t *testing.T) {
+		other := signTestToken(t, jwt.MapClaims{"uid": "uid-1", "username": "admin", "exp": expiry + 1})
+		_, err := TokenLogin(context.Background(), server.URL, other)
+		if !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("TokenLogin() error = %v, want %v", err, ErrUnauthenticated)
+		}
+	})
+
+	t.Run("login with a token that is not a JWT", func(t *testing.T) {
+		if _, err := TokenLogin(context.Background(), server.URL, "not-a-token"); err == nil {
+			t.Error("TokenLogin() expected an error")
+		}
+	})
+
+	cred := types.Credentials{Endpoint: server.URL, Token: userToken}
+
+	t.Run("create list and revoke", func(t *testing.T) {
+		token, err := CreateAPIToken(context.Background(), cred, "ci", 7)
+		if err != nil || token != "new-api-token" {
+			t.Fatalf("CreateAPIToken() = %q, %v", token, err)
+		}
+
+		tokens, err := ListAPITokens(context.Background(), cred)
+		if err != nil || len(tokens) != 1 || tokens[0].Name != "ci" {
+			t.Fatalf("ListAPITokens() = %+v, %v", tokens, err)
+		}
+
+		if err := RevokeAPIToken(context.Background(), cred, tokens[0].Token); err != nil {
+			t.Fatalf("RevokeAPIToken() unexpected error = %v", err)
+		}
+		if revoked != "new-api-token" {
+			t.Errorf("RevokeAPIToken() revoked %q, want %q", revoked, "new-api-token")
+		}
+	})
+}
+
+func TestLogout(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantKind error
+	}{
+		{name: "token revoked", status: http.StatusOK},
+		{name: "token already expired", status: http.StatusUnauthorized, wantKind: ErrUnauthenticated},
+		{name: "server failure", status: http.StatusInternalServerError, wantKind: ErrServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/auth/logout" || r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := Logout(context.Background(), types.Credentials{Endpoint: server.URL, Token: "token"})
+			if tt.wantKind == nil && err != nil {
+				t.Fatalf("Logout() unexpected error = %v", err)
+			}
+			if tt.wantKind != nil && !errors.Is(err, tt.wantKind) {
+				t.Errorf("Logout() error = %v, want %v", err, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		closed   bool
+		wantKind error
+	}{
+		{name: "server up", status: http.StatusOK},
+		{name: "server failure", status: http.StatusServiceUnavailable, wantKind: ErrServer},
+		{name: "server unreachable", closed: true, wantKind: ErrNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/auth/status" || r.Method != http.MethodGet {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			if tt.closed {
+				server.Close()
+			}
+			defer server.Close()
+
+			err := Status(context.Background(), server.URL, http.DefaultTransport)
+			if tt.wantKind == nil && err != nil {
+				t.Fatalf("Status() unexpected error = %v", err)
+			}
+			if tt.wantKind != nil && !errors.Is(err, tt.wantKind) {
+				t.Errorf("Status() error = %v, want %v", err, tt.wantKind)
+			}
+			if requests > 1 {
+				t.Errorf("Status() sent %d requests, want at most 1", requests)
+			}
+		})
+	}
+}
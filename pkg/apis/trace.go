@@ -0,0 +1,170 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// maxTracedBody is the number of bytes of a body written to the trace
+const maxTracedBody = 16 * 1024
+
+var (
+	// traceLevel is the verbosity of the wire trace, 0 disables it. Level 1
+	// traces every request, level 2 also traces headers, variables and bodies.
+	traceLevel = 0
+	tracer     = logrus.New()
+
+	operationName = regexp.MustCompile(`^\s*(query|mutation|subscription)\s+(\w+)`)
+)
+
+// ConfigureTracing enables the wire trace of the requests sent to the portal at
+// the given level, written to out as text or json
+func ConfigureTracing(level int, out io.Writer, format string) error {
+	logger := logrus.New()
+	logger.SetOutput(out)
+	switch format {
+	case "text", "":
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	case "json":
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return errors.New("unsupported log format " + format + ", use one of text|json")
+	}
+
+	traceLevel = level
+	tracer = logger
+	return nil
+}
+
+// traceRequest writes the request and its outcome to the trace. At level 2 the
+// response body is read to be traced, and replaced so that callers can still read it.
+func traceRequest(req *http.Request, payload []byte, resp *http.Response, err error, latency time.Duration) {
+	fields := logrus.Fields{
+		"method":  req.Method,
+		"url":     req.URL.Redacted(),
+		"latency": latency.String(),
+	}
+
+	var gqlRequest struct {
+		Query     string          `json:"query"`
+		Variables json.RawMessage `json:"variables"`
+	}
+	isGraphQL := json.Unmarshal(payload, &gqlRequest) == nil && gqlRequest.Query != ""
+	if isGraphQL {
+		if match := operationName.FindStringSubmatch(gqlRequest.Query); match != nil {
+			fields["operation"] = match[2]
+		}
+	}
+
+	if traceLevel >= 2 {
+		headers := map[string]string{}
+		for name := range req.Header {
+			if value := req.Header.Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		if headers["Authorization"] != "" {
+			headers["Authorization"] = config.RedactedValue
+		}
+		fields["headers"] = headers
+
+		if isGraphQL {
+			fields["variables"] = redactBody(gqlRequest.Variables)
+		} else if len(payload) > 0 {
+			fields["body"] = redactBody(payload)
+		}
+	}
+
+	if err != nil {
+		tracer.WithFields(fields).WithError(err).Warn("request failed")
+		return
+	}
+	fields["status"] = resp.StatusCode
+
+	if traceLevel >= 2 && resp.Body != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if readErr != nil {
+			fields["responseError"] = readErr.Error()
+		}
+		fields["response"] = redactBody(body)
+	}
+
+	tracer.WithFields(fields).Info("request sent")
+}
+
+// redactBody returns the body with the values of the passwords, tokens and
+// secrets it holds replaced by config.RedactedValue. Bodies that are not
+// JSON are returned as is, truncated to maxTracedBody bytes.
+func redactBody(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		if redacted, err := json.Marshal(redactValue(value)); err == nil {
+			body = redacted
+		}
+	}
+
+	if len(body) > maxTracedBody {
+		return string(body[:maxTracedBody]) + "... (" + strconv.Itoa(len(body)-maxTracedBody) + " more bytes)"
+	}
+	return string(body)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSecretKey(key) {
+				if item != nil && item != "" {
+					v[key] = config.RedactedValue
+				}
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+// isSecretKey reports whether the JSON key names a password, a token or a secret
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range []string{"password", "token", "secret", "authorization"} {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+type secretVariables struct {
+	ProjectID string `json:"projectID"`
+	Password  string `json:"password"`
+}
+
+func TestTracing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data":{"name":"exp","token":"response-secret"}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		level       int
+		wantFields  []string
+		wantMissing []string
+	}{
+		{
+			name:        "requests only",
+			level:       1,
+			wantFields:  []string{"method", "url", "operation", "status", "latency"},
+			wantMissing: []string{"headers", "variables", "response"},
+		},
+		{
+			name:       "headers, variables and bodies",
+			level:      2,
+			wantFields: []string{"method", "url", "operation", "status", "latency", "headers", "variables", "response"},
+		},
+	}
+
+	defer ConfigureTracing(0, io.Discard, "text")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := ConfigureTracing(tt.level, &out, "json"); err != nil {
+				t.Fatalf("ConfigureTracing() unexpected error = %v", err)
+			}
+
+			client := GraphQLClient{Endpoint: server.URL, Token: "Bearer request-secret"}
+			data, err := Query[testData](context.Background(), client, "query getExperiment($projectID: ID!) { name }", secretVariables{ProjectID: "p1", Password: "variable-secret"})
+			if err != nil {
+				t.Fatalf("Query() unexpected error = %v", err)
+			}
+			if data.Name != "exp" {
+				t.Errorf("Query() name = %q, want the response body to be readable after tracing", data.Name)
+			}
+
+			trace := out.String()
+			for _, secret := range []string{"request-secret", "variable-secret", "response-secret"} {
+				if strings.Contains(trace, secret) {
+					t.Errorf("Trace contains %q: %s", secret, trace)
+				}
+			}
+
+			var entry map[string]interface{}
+			if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+				t.Fatalf("Trace is not a JSON entry: %v", err)
+			}
+			for _, field := range tt.wantFields {
+				if _, ok := entry[field]; !ok {
+					t.Errorf("Trace misses %q: %s", field, trace)
+				}
+			}
+			for _, field := range tt.wantMissing {
+				if _, ok := entry[field]; ok {
+					t.Errorf("Trace has %q at level %d: %s", field, tt.level, trace)
+				}
+			}
+			if entry["operation"] != "getExperiment" || entry["method"] != string(types.Post) {
+				t.Errorf("Trace operation = %v, method = %v, want getExperiment and POST", entry["operation"], entry["method"])
+			}
+		})
+	}
+}
+
+func TestConfigureTracingFormat(t *testing.T) {
+	defer ConfigureTracing(0, io.Discard, "text")
+
+	if err := ConfigureTracing(1, io.Discard, "xml"); err == nil {
+		t.Error("ConfigureTracing() expected an error for an unsupported format")
+	}
+}
@@ -3,14 +3,11 @@ package apis
 import (
 	"bytes"
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
-	"github.com/sirupsen/logrus"
-	"io"
-	"net/http"
 	"os"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/litmuschaos/litmusctl/pkg/k8s"
 	"github.com/litmuschaos/litmusctl/pkg/types"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
@@ -18,24 +15,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type manifestData struct {
-	Data   data `json:"data"`
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
-}
-
 type data struct {
 	GetManifest string `json:"getInfraManifest"`
 }
 
 type GetInfraResponse struct {
-	Data   GetInfraData `json:"data"`
-	Errors []struct {
-		Message string   `json:"message"`
-		Path    []string `json:"path"`
-	} `json:"errors"`
+	Data   GetInfraData  `json:"data"`
+	Errors GraphQLErrors `json:"errors"`
 }
 
 type GetInfraData struct {
@@ -47,107 +33,103 @@ type InfraDetails struct {
 	InfraNamespace *string `json:"infraNamespace"`
 }
 
+type GetInfraDetailsGraphQLRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID string `json:"projectID"`
+		InfraID   string `json:"infraID"`
+	} `json:"variables"`
+}
+
+type GetInfraManifestGraphQLRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID string `json:"projectID"`
+		InfraID   string `json:"infraID"`
+		Upgrade   bool   `json:"upgrade"`
+	} `json:"variables"`
+}
+
 func UpgradeInfra(c context.Context, cred types.Credentials, projectID string, infraID string, kubeconfig string) (string, error) {
 
+	client := NewGraphQLClient(cred)
+
 	// Query to fetch Infra details from server
-	query := `{"query":"query {\n getInfraDetails(infraID : \"` + infraID + `\", \n projectID : \"` + projectID + `\"){\n infraNamespace infraID \n}}"}`
-	resp, err := SendRequest(SendRequestParams{Endpoint: cred.Endpoint + utils.GQLAPIPath, Token: cred.Token}, []byte(query), string(types.Post))
+	infra, err := getInfraDetails(c, client, projectID, infraID)
 	if err != nil {
 		return "", err
 	}
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	// Query to fetch upgraded manifest from the server
+	manifest, err := getUpgradeManifest(c, client, projectID, infra.GetInfraDetails.InfraID)
 	if err != nil {
 		return "", err
 	}
 
-	defer resp.Body.Close()
-	var infra GetInfraResponse
-
-	if resp.StatusCode == http.StatusOK {
-		err = json.Unmarshal(bodyBytes, &infra)
-		if err != nil {
-			return "", err
-		}
-		if len(infra.Errors) > 0 {
-			return "", errors.New(infra.Errors[0].Message)
-		}
-	} else {
-		return "", errors.New(resp.Status)
-	}
-
-	// Query to fetch upgraded manifest from the server
-	query = `{"query":"query {\n getInfraManifest(projectID : \"` + projectID + `\",\n infraID : \"` + infra.Data.GetInfraDetails.InfraID + `\", \n upgrade: true)}"}`
-	resp, err = SendRequest(SendRequestParams{Endpoint: cred.Endpoint + utils.GQLAPIPath, Token: cred.Token}, []byte(query), string(types.Post))
+	// Fetching subscriber-config from the subscriber
+	configData, err := k8s.GetConfigMap(c, "subscriber-config", *infra.GetInfraDetails.InfraNamespace)
 	if err != nil {
 		return "", err
 	}
+	var configMapString string
+
+	metadata := new(bytes.Buffer)
+	fmt.Fprintf(metadata, "\n%s: %s\n%s: %s\n%s: \n  %s: %s\n  %s: %s\n%s:\n", "apiVersion", "v1",
+		"kind", "ConfigMap", "metadata", "name", "subscriber-config", "namespace", *infra.GetInfraDetails.InfraNamespace, "data")
+
+	for k, v := range configData {
+		b := new(bytes.Buffer)
+		if k == "COMPONENTS" {
+			fmt.Fprintf(b, "  %s: |\n    %s", k, v)
+		} else if k == "START_TIME" || k == "IS_INFRA_CONFIRMED" {
+			fmt.Fprintf(b, "  %s: \"%s\"\n", k, v)
+		} else {
+			fmt.Fprintf(b, "  %s: %s\n", k, v)
+		}
+		configMapString = configMapString + b.String()
+
+	}
 
-	bodyBytes, err = io.ReadAll(resp.Body)
+	yamlOutput, err := k8s.ApplyManifest(c, []byte(manifest.GetManifest), kubeconfig)
 	if err != nil {
 		return "", err
 	}
+	logrus.Println("🚀 Successfully Upgraded Chaos Infrastructure")
 
-	defer resp.Body.Close()
+	utils.White.Print("\n", yamlOutput)
 
-	// Checks if status code is OK(200)
-	if resp.StatusCode == http.StatusOK {
-		var manifest manifestData
-		err = json.Unmarshal(bodyBytes, &manifest)
-		if err != nil {
-			return "", err
-		}
+	// Creating a backup for current subscriber-config in the SUBSCRIBER
+	home, err := homedir.Dir()
+	cobra.CheckErr(err)
 
-		if len(manifest.Errors) > 0 {
-			return "", errors.New(manifest.Errors[0].Message)
-		}
+	configMapString = metadata.String() + configMapString
+	err = os.WriteFile(home+"/backupSubscriberConfig.yaml", []byte(configMapString), 0644)
+	if err != nil {
+		return "Error creating backup for subscriber config: ", err
+	}
 
-		// Fetching subscriber-config from the subscriber
-		configData, err := k8s.GetConfigMap(c, "subscriber-config", *infra.Data.GetInfraDetails.InfraNamespace)
-		if err != nil {
-			return "", err
-		}
-		var configMapString string
-
-		metadata := new(bytes.Buffer)
-		fmt.Fprintf(metadata, "\n%s: %s\n%s: %s\n%s: \n  %s: %s\n  %s: %s\n%s:\n", "apiVersion", "v1",
-			"kind", "ConfigMap", "metadata", "name", "subscriber-config", "namespace", *infra.Data.GetInfraDetails.InfraNamespace, "data")
-
-		for k, v := range configData {
-			b := new(bytes.Buffer)
-			if k == "COMPONENTS" {
-				fmt.Fprintf(b, "  %s: |\n    %s", k, v)
-			} else if k == "START_TIME" || k == "IS_INFRA_CONFIRMED" {
-				fmt.Fprintf(b, "  %s: \"%s\"\n", k, v)
-			} else {
-				fmt.Fprintf(b, "  %s: %s\n", k, v)
-			}
-			configMapString = configMapString + b.String()
+	utils.White_B.Print("\n ** A backup of subscriber-config configmap has been saved in your system's home directory as backupSubscriberConfig.yaml **\n")
 
-		}
+	return "Manifest applied successfully", nil
+}
 
-		yamlOutput, err := k8s.ApplyManifest([]byte(manifest.Data.GetManifest), kubeconfig)
-		if err != nil {
-			return "", err
-		}
-		logrus.Println("🚀 Successfully Upgraded Chaos Infrastructure")
+func getInfraDetails(c context.Context, client GraphQLClient, projectID string, infraID string) (GetInfraData, error) {
+	var gqlReq GetInfraDetailsGraphQLRequest
 
-		utils.White.Print("\n", yamlOutput)
+	gqlReq.Query = GetInfraDetailsQuery
+	gqlReq.Variables.ProjectID = projectID
+	gqlReq.Variables.InfraID = infraID
 
-		// Creating a backup for current subscriber-config in the SUBSCRIBER
-		home, err := homedir.Dir()
-		cobra.CheckErr(err)
+	return Query[GetInfraData](c, client, gqlReq.Query, gqlReq.Variables)
+}
 
-		configMapString = metadata.String() + configMapString
-		err = os.WriteFile(home+"/backupSubscriberConfig.yaml", []byte(configMapString), 0644)
-		if err != nil {
-			return "Error creating backup for subscriber config: ", err
-		}
+func getUpgradeManifest(c context.Context, client GraphQLClient, projectID string, infraID string) (data, error) {
+	var gqlReq GetInfraManifestGraphQLRequest
 
-		utils.White_B.Print("\n ** A backup of subscriber-config configmap has been saved in your system's home directory as backupSubscriberConfig.yaml **\n")
+	gqlReq.Query = GetInfraManifestQuery
+	gqlReq.Variables.ProjectID = projectID
+	gqlReq.Variables.InfraID = infraID
+	gqlReq.Variables.Upgrade = true
 
-		return "Manifest applied successfully", nil
-	} else {
-		return "GQL error: ", errors.New("Unmatched status code:" + string(bodyBytes))
-	}
+	return Query[data](c, client, gqlReq.Query, gqlReq.Variables)
 }
@@ -0,0 +1,102 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// adversarialIDs are identifiers that would break out of a GraphQL string
+// literal if they were pasted into the query document
+var adversarialIDs = []string{
+	`plain-id`,
+	`id"with"quotes`,
+	`id\with\backslashes\`,
+	`"){ deleteInfra(projectID: "x", infraID: "y") }#`,
+	"id\nwith\nnewlines",
+}
+
+type upgradeRequest struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProjectID string `json:"projectID"`
+		InfraID   string `json:"infraID"`
+		Upgrade   *bool  `json:"upgrade"`
+	} `json:"variables"`
+}
+
+func TestUpgradeQueriesUseVariables(t *testing.T) {
+	for _, id := range adversarialIDs {
+		t.Run(id, func(t *testing.T) {
+			var requests []upgradeRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req upgradeRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("Failed to decode request: %v", err)
+				}
+				requests = append(requests, req)
+				if strings.HasPrefix(req.Query, "query getInfraDetails") {
+					w.Write([]byte(`{"data":{"getInfraDetails":{"infraID":"` + jsonEscape(t, req.Variables.InfraID) + `"}}}`))
+					return
+				}
+				w.Write([]byte(`{"data":{"getInfraManifest":"manifest"}}`))
+			}))
+			defer server.Close()
+
+			client := GraphQLClient{Endpoint: server.URL}
+			infra, err := getInfraDetails(context.Background(), client, id, id)
+			if err != nil {
+				t.Fatalf("getInfraDetails() unexpected error = %v", err)
+			}
+			manifest, err := getUpgradeManifest(context.Background(), client, id, infra.GetInfraDetails.InfraID)
+			if err != nil {
+				t.Fatalf("getUpgradeManifest() unexpected error = %v", err)
+			}
+			if manifest.GetManifest != "manifest" {
+				t.Errorf("getUpgradeManifest() = %q, want %q", manifest.GetManifest, "manifest")
+			}
+
+			wantQueries := []string{GetInfraDetailsQuery, GetInfraManifestQuery}
+			if len(requests) != len(wantQueries) {
+				t.Fatalf("Server received %d requests, want %d", len(requests), len(wantQueries))
+			}
+			for i, req := range requests {
+				if req.Query != wantQueries[i] {
+					t.Errorf("Request %d query = %q, want %q", i, req.Query, wantQueries[i])
+				}
+				if req.Variables.ProjectID != id || req.Variables.InfraID != id {
+					t.Errorf("Request %d variables = %+v, want both IDs to be %q", i, req.Variables, id)
+				}
+			}
+			if upgrade := requests[1].Variables.Upgrade; upgrade == nil || !*upgrade {
+				t.Errorf("getInfraManifest upgrade variable = %v, want true", upgrade)
+			}
+		})
+	}
+}
+
+func jsonEscape(t *testing.T, s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Failed to marshal %q: %v", s, err)
+	}
+	return string(b[1 : len(b)-1])
+}
@@ -0,0 +1,211 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package apply
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// Results of applying a manifest
+const (
+	created    = "created"
+	configured = "configured"
+	unchanged  = "unchanged"
+)
+
+// ApplyCmd represents the apply command
+var ApplyCmd = &cobra.Command{
+	Use: "apply",
+	Short: `Create or update Chaos Experiments from their manifests
+		Examples:
+		#apply every Chaos Experiment of a directory
+		litmusctl apply -f experiments/ --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --chaos-infra-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c"
+
+		#apply Chaos Experiments matching a glob and from a URL
+		litmusctl apply -f "experiments/*.yaml" -f https://example.com/chaos-experiment.yaml --project-id="d861b650-1549-4574-b2ba-ab754058dd04"
+
+		Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
+	`,
+	Long: `Create or update Chaos Experiments from their manifests. A Chaos Experiment is looked up by name in the project,
+and is updated in place when it exists or created otherwise. Manifests using generateName update the experiment
+previously created from them. Experiments are saved without being run.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		files, err := cmd.Flags().GetStringArray("file")
+		utils.PrintError(err)
+		if len(files) == 0 {
+			utils.Red.Println("⛔ Pass the manifests to apply with -f")
+			os.Exit(1)
+		}
+
+		pid, err := utils.GetProjectID(cmd)
+		utils.PrintError(err)
+		if pid == "" {
+			utils.Red.Println("⛔ Project ID can't be empty, pass it with --project-id")
+			os.Exit(1)
+		}
+
+		infraID, err := utils.GetInfraID(cmd)
+		utils.PrintError(err)
+
+		var description *string
+		if cmd.Flags().Changed("description") {
+			value, err := cmd.Flags().GetString("description")
+			utils.PrintError(err)
+			description = &value
+		}
+
+		manifests, err := utils.ExpandManifestPaths(files)
+		utils.PrintError(err)
+
+		// Perform authorization
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
+		utils.PrintError(err)
+		var editAccess = false
+		var project apis.Project
+		for _, p := range userDetails.Data.Projects {
+			if p.ID == pid {
+				project = p
+			}
+		}
+		for _, member := range project.Members {
+			if (member.UserID == userDetails.Data.ID) && (member.Role == "Owner" || member.Role == "Editor") {
+				editAccess = true
+			}
+		}
+		if !editAccess {
+			utils.Red.Println("⛔ User doesn't have edit access to the project!!")
+			os.Exit(1)
+		}
+
+		var failure error
+		for _, manifest := range manifests {
+			body, err := utils.ReadManifest(manifest)
+			if err != nil {
+				utils.Red.Println("❌ Failed to read " + manifest + ": " + err.Error())
+				failure = errors.Join(failure, err)
+				continue
+			}
+
+			documents := utils.SplitManifests(body)
+			for i, document := range documents {
+				source := manifest
+				if len(documents) > 1 {
+					source += " (document " + strconv.Itoa(i+1) + ")"
+				}
+
+				name, result, err := applyExperiment(cmd.Context(), pid, infraID, description, document, credentials)
+				if err != nil {
+					utils.Red.Println("❌ Failed to apply " + source + ": " + err.Error())
+					failure = errors.Join(failure, err)
+					continue
+				}
+				utils.White_B.Println("chaos-experiment/" + name + " " + result)
+			}
+		}
+
+		if failure != nil {
+			os.Exit(utils.ExitCode(failure))
+		}
+	},
+}
+
+// applyExperiment saves the Chaos Experiment of the manifest, unless the
+// experiment of the same name already matches it. It returns the name of the
+// experiment and whether it was created, configured or left unchanged.
+func applyExperiment(ctx context.Context, pid string, infraID string, description *string, manifest []byte, credentials types.Credentials) (string, string, error) {
+	var request models.SaveChaosExperimentRequest
+	if err := utils.ParseExperimentManifestData(manifest, &request); err != nil {
+		return "", "", err
+	}
+
+	name, generateName, err := utils.ManifestName(manifest)
+	if err != nil {
+		return "", "", err
+	}
+
+	existing, err := experiment.FindExperiment(ctx, pid, name, generateName, credentials)
+	if err != nil {
+		return "", "", err
+	}
+
+	if existing == nil {
+		if infraID == "" {
+			return request.Name, "", errors.New("chaos-infra-id is required to create Chaos Experiment " + request.Name)
+		}
+		request.ID = utils.GenerateNameID(request.Name)
+		request.InfraID = infraID
+		if description != nil {
+			request.Description = *description
+		}
+
+		_, err = experiment.SaveExperiment(ctx, pid, request, credentials)
+		return request.Name, created, err
+	}
+
+	// Keep the name generated when the experiment was first created
+	if name == "" {
+		manifest, err = utils.SetManifestName(manifest, existing.Name)
+		if err != nil {
+			return "", "", err
+		}
+		request = models.SaveChaosExperimentRequest{}
+		if err := utils.ParseExperimentManifestData(manifest, &request); err != nil {
+			return "", "", err
+		}
+	}
+	request.ID = existing.ExperimentID
+	request.Description = existing.Description
+	request.Tags = existing.Tags
+	if description != nil {
+		request.Description = *description
+	}
+	request.InfraID = infraID
+	if request.InfraID == "" && existing.Infra != nil {
+		request.InfraID = existing.Infra.InfraID
+	}
+
+	same, err := utils.ManifestsEqual([]byte(existing.ExperimentManifest), []byte(request.Manifest))
+	if err != nil {
+		return request.Name, "", err
+	}
+	if same && request.Description == existing.Description && existing.Infra != nil && request.InfraID == existing.Infra.InfraID {
+		return request.Name, unchanged, nil
+	}
+
+	_, err = experiment.SaveExperiment(ctx, pid, request, credentials)
+	return request.Name, configured, err
+}
+
+func init() {
+	ApplyCmd.Flags().StringArrayP("file", "f", nil, "The manifests of the Chaos Experiments, as files, directories, globs or URLs. Can be repeated")
+	ApplyCmd.Flags().String("project-id", "", "Set the project-id to apply the Chaos Experiments to the particular project. To see the projects, apply litmusctl get projects")
+	ApplyCmd.Flags().String("chaos-infra-id", "", "Set the chaos-infra-id to run new Chaos Experiments on the particular Chaos Infrastructure. To see the Chaos Infrastructures, apply litmusctl get chaos-infra")
+	ApplyCmd.Flags().StringP("description", "d", "", "The Description for the Chaos Experiments")
+}
@@ -0,0 +1,181 @@
+package apply
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/apitest"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+const testManifest = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  %s
+  namespace: litmus
+spec:
+  entrypoint: pod-delete
+  templates:
+    - name: pod-delete
+      container:
+        image: litmuschaos/k8s:latest
+`
+
+// fakeServer answers listExperiment with the given experiments and records
+// the experiments saved
+func fakeServer(t *testing.T, experiments []*models.Experiment, saved *[]models.SaveChaosExperimentRequest) *httptest.Server {
+	return apitest.NewServer(t, map[string]apitest.Handler{
+		"listExperiment": apitest.Respond(models.ListExperimentResponse{Experiments: experiments}),
+		"saveChaosExperiment": func(request apitest.Request) (interface{}, error) {
+			var save models.SaveChaosExperimentRequest
+			if err := request.Decode("request", &save); err != nil {
+				return nil, err
+			}
+			*saved = append(*saved, save)
+			return "experiment saved", nil
+		},
+	})
+}
+
+func TestApplyExperiment(t *testing.T) {
+	named := []byte(strings.Replace(testManifest, "%s", "name: pod-delete", 1))
+	generated := []byte(strings.Replace(testManifest, "%s", "generateName: pod-delete-", 1))
+	changedImage := []byte(strings.Replace(string(named), "k8s:latest", "k8s:3.0.0", 1))
+	description := "updated"
+
+	existing := func(name string, manifest []byte) *models.Experiment {
+		return &models.Experiment{
+			ExperimentID:       "pod_delete",
+			Name:               name,
+			Description:        "first",
+			Tags:               []string{"team-a", "nightly"},
+			ExperimentManifest: strings.Replace(string(manifest), "generateName: pod-delete-", "name: "+name, 1),
+			Infra:              &models.Infra{InfraID: "infra-1"},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		experiments []*models.Experiment
+		manifest    []byte
+		infraID     string
+		description *string
+		wantName    string
+		wantResult  string
+		wantSaved   *models.SaveChaosExperimentRequest
+		wantErr     bool
+	}{
+		{
+			name:       "new experiment",
+			manifest:   named,
+			infraID:    "infra-1",
+			wantName:   "pod-delete",
+			wantResult: created,
+			wantSaved:  &models.SaveChaosExperimentRequest{ID: "pod_delete", Name: "pod-delete", InfraID: "infra-1"},
+		},
+		{
+			name:     "new experiment without infra",
+			manifest: named,
+			wantErr:  true,
+		},
+		{
+			name:        "same experiment",
+			experiments: []*models.Experiment{existing("pod-delete", named)},
+			manifest:    named,
+			wantName:    "pod-delete",
+			wantResult:  unchanged,
+		},
+		{
+			name:        "changed manifest",
+			experiments: []*models.Experiment{existing("pod-delete", named)},
+			manifest:    changedImage,
+			wantName:    "pod-delete",
+			wantResult:  configured,
+			wantSaved:   &models.SaveChaosExperimentRequest{ID: "pod_delete", Name: "pod-delete", Description: "first", InfraID: "infra-1", Tags: []string{"team-a", "nightly"}},
+		},
+		{
+			name:        "changed description",
+			experiments: []*models.Experiment{existing("pod-delete", named)},
+			manifest:    named,
+			description: &description,
+			wantName:    "pod-delete",
+			wantResult:  configured,
+			wantSaved:   &models.SaveChaosExperimentRequest{ID: "pod_delete", Name: "pod-delete", Description: "updated", InfraID: "infra-1", Tags: []string{"team-a", "nightly"}},
+		},
+		{
+			name:        "experiment with a similar name",
+			experiments: []*models.Experiment{existing("pod-delete-2", named)},
+			manifest:    named,
+			infraID:     "infra-1",
+			wantName:    "pod-delete",
+			wantResult:  created,
+			wantSaved:   &models.SaveChaosExperimentRequest{ID: "pod_delete", Name: "pod-delete", InfraID: "infra-1"},
+		},
+		{
+			name:        "experiment generated from the manifest",
+			experiments: []*models.Experiment{existing("pod-delete-ab12c", generated)},
+			manifest:    generated,
+			wantName:    "pod-delete-ab12c",
+			wantResult:  unchanged,
+		},
+		{
+			name:        "changed experiment generated from the manifest",
+			experiments: []*models.Experiment{existing("pod-delete-ab12c", generated)},
+			manifest:    []byte(strings.Replace(string(generated), "k8s:latest", "k8s:3.0.0", 1)),
+			wantName:    "pod-delete-ab12c",
+			wantResult:  configured,
+			wantSaved:   &models.SaveChaosExperimentRequest{ID: "pod_delete", Name: "pod-delete-ab12c", Description: "first", InfraID: "infra-1", Tags: []string{"team-a", "nightly"}},
+		},
+		{
+			name:        "several experiments generated from the manifest",
+			experiments: []*models.Experiment{existing("pod-delete-ab12c", generated), existing("pod-delete-xy34z", generated)},
+			manifest:    generated,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved []models.SaveChaosExperimentRequest
+			server := fakeServer(t, tt.experiments, &saved)
+			defer server.Close()
+
+			credentials := types.Credentials{Endpoint: server.URL, ServerEndpoint: server.URL, Token: "token"}
+			name, result, err := applyExperiment(context.Background(), "project-1", tt.infraID, tt.description, tt.manifest, credentials)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyExperiment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if name != tt.wantName || result != tt.wantResult {
+				t.Errorf("applyExperiment() = %q, %q, want %q, %q", name, result, tt.wantName, tt.wantResult)
+			}
+
+			if tt.wantSaved == nil {
+				if len(saved) != 0 {
+					t.Errorf("applyExperiment() saved %+v, want nothing saved", saved)
+				}
+				return
+			}
+			if len(saved) != 1 {
+				t.Fatalf("applyExperiment() saved %d experiments, want 1", len(saved))
+			}
+			got := saved[0]
+			if got.ID != tt.wantSaved.ID || got.Name != tt.wantSaved.Name || got.Description != tt.wantSaved.Description || got.InfraID != tt.wantSaved.InfraID || !reflect.DeepEqual(got.Tags, tt.wantSaved.Tags) {
+				t.Errorf("applyExperiment() saved %+v, want %+v", got, tt.wantSaved)
+			}
+			if !strings.Contains(got.Manifest, `"name":"`+tt.wantName+`"`) {
+				t.Errorf("Saved manifest %s doesn't use the name %s", got.Manifest, tt.wantName)
+			}
+			// Only metadata.name is renamed, not the templates
+			if strings.Contains(got.Manifest, "generateName") || !strings.Contains(got.Manifest, `"name":"pod-delete"`) {
+				t.Errorf("Saved manifest %s, want the generateName replaced by metadata.name only", got.Manifest)
+			}
+		})
+	}
+}
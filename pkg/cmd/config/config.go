@@ -33,8 +33,35 @@ var ConfigCmd = &cobra.Command{
 		#get all accounts in the config file
 		litmusctl config get-accounts
 		
+		#create a context with a default project, infra and kubeconfig
+		litmusctl config set-context prod --project-id "" --chaos-infra-id "" --kubeconfig ""
+
+		#switch to a context
+		litmusctl config use-context prod
+
+		#get all contexts in the config file
+		litmusctl config get-contexts
+
+		#revoke the token of the current account and remove it
+		litmusctl config logout
+
+		#remove an account, or one of its users
+		litmusctl config delete-account --endpoint "" --username ""
+
+		#move an account to a new endpoint
+		litmusctl config rename-account --endpoint "" --new-endpoint ""
+
+		#remove users whose token has expired
+		litmusctl config prune
+
+		#renew the token of the current account
+		litmusctl config refresh
+
 		#view the config file
 		litmusctl config view
+
+		#check the config file for problems
+		litmusctl config validate
 		
 		Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
 		`,
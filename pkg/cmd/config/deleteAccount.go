@@ -0,0 +1,77 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// deleteAccountCmd represents the deleteAccount command
+var deleteAccountCmd = &cobra.Command{
+	Use: "delete-account",
+	Short: `Removes an account, or one of its users, from a litmusconfig file.
+		Examples(s)
+		#remove an account with all its users
+		litmusctl config delete-account --endpoint ""
+
+		#remove a single user of an account
+		litmusctl config delete-account --endpoint "" --username ""
+
+		Note: The token is only removed locally, use 'litmusctl config logout' to also revoke it
+		`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		endpoint, err := cmd.Flags().GetString("endpoint")
+		utils.PrintError(err)
+
+		if endpoint == "" {
+			utils.White_B.Print("\nHost endpoint where litmus is installed: ")
+			fmt.Scanln(&endpoint)
+
+			if endpoint == "" {
+				utils.Red.Println("\n⛔ Host URL can't be empty!!")
+				os.Exit(1)
+			}
+		}
+
+		username, err := cmd.Flags().GetString("username")
+		utils.PrintError(err)
+
+		err = config.ConfigSyntaxCheck(configFilePath)
+		utils.PrintError(err)
+
+		err = config.DeleteAccount(endpoint, username, configFilePath)
+		utils.PrintError(err)
+
+		if username != "" {
+			fmt.Printf("\n✅ Removed user '%s' of account '%s'\n", username, endpoint)
+		} else {
+			fmt.Printf("\n✅ Removed account '%s'\n", endpoint)
+		}
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(deleteAccountCmd)
+	deleteAccountCmd.Flags().StringP("endpoint", "e", "", "Set the endpoint of the account to remove")
+	deleteAccountCmd.Flags().StringP("username", "u", "", "Set the username to remove only this user of the account")
+}
@@ -16,47 +16,184 @@ limitations under the License.
 package config
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"sync"
 	"text/tabwriter"
 	"time"
 
+	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
+// reachabilityTimeout bounds the check of whether an account is reachable
+const reachabilityTimeout = 10 * time.Second
+
+// accountStatus describes a user of an account, as displayed by get-accounts
+type accountStatus struct {
+	Current        bool   `json:"current" yaml:"current"`
+	Endpoint       string `json:"endpoint" yaml:"endpoint"`
+	ServerEndpoint string `json:"serverEndpoint" yaml:"serverEndpoint"`
+	Username       string `json:"username" yaml:"username"`
+	ExpiresAt      string `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	Remaining      string `json:"remaining,omitempty" yaml:"remaining,omitempty"`
+	Expired        bool   `json:"expired" yaml:"expired"`
+	Reachable      bool   `json:"reachable" yaml:"reachable"`
+	Token          string `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
 // getAccountsCmd represents the getAccounts command
 var getAccountsCmd = &cobra.Command{
 	Use:   "get-accounts",
 	Short: "Display accounts defined in the litmusconfig",
-	Long:  `Display accounts defined in the litmusconfig`,
+	Long: `Display accounts defined in the litmusconfig, with the expiry of their tokens and whether they are reachable.
+Tokens are redacted unless --show-secrets is passed.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		configFilePath := utils.GetLitmusConfigPath(cmd)
 
-		obj, err := config.YamltoObject(configFilePath)
+		output, err := cmd.Flags().GetString("output")
+		utils.PrintError(err)
+		showSecrets, err := cmd.Flags().GetBool("show-secrets")
 		utils.PrintError(err)
 
-		writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-		utils.White_B.Fprintln(writer, "CURRENT\tENDPOINT\tUSERNAME\tEXPIRESIN")
-		for _, account := range obj.Accounts {
-			for _, user := range account.Users {
-				intTime, err := strconv.ParseInt(user.ExpiresIn, 10, 64)
-				utils.PrintError(err)
+		obj, err := readConfig(configFilePath, showSecrets)
+		utils.PrintError(err)
 
-				humanTime := time.Unix(intTime, 0)
+		statuses := accountStatuses(obj, time.Now())
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		checkReachable(ctx, obj, statuses)
 
-				if obj.CurrentUser == user.Username && obj.CurrentAccount == account.Endpoint {
-					utils.White.Fprintln(writer, "*"+"\t"+account.Endpoint+"\t"+user.Username+"\t"+humanTime.String())
-				} else {
-					utils.White.Fprintln(writer, ""+"\t"+account.Endpoint+"\t"+user.Username+"\t"+humanTime.String())
-				}
-			}
+		switch output {
+		case "json":
+			utils.PrintInJsonFormat(statuses)
+		case "yaml":
+			utils.PrintInYamlFormat(statuses)
+		case "table", "":
+			printAccountsTable(statuses, true, showSecrets)
+		default:
+			utils.PrintError(errors.New("unsupported output format " + output + ", use one of json|yaml|table"))
 		}
-		writer.Flush()
 	},
 }
 
+// accountStatuses lists the users of every account of obj, with the expiry of their tokens at now
+func accountStatuses(obj types.LitmuCtlConfig, now time.Time) []accountStatus {
+	statuses := []accountStatus{}
+	for _, account := range obj.Accounts {
+		for _, user := range account.Users {
+			status := accountStatus{
+				Current:        obj.CurrentUser == user.Username && obj.CurrentAccount == account.Endpoint,
+				Endpoint:       account.Endpoint,
+				ServerEndpoint: account.ServerEndpoint,
+				Username:       user.Username,
+				Token:          user.Token,
+			}
+
+			if expiresIn, err := strconv.ParseInt(user.ExpiresIn, 10, 64); err == nil {
+				expiresAt := time.Unix(expiresIn, 0)
+				status.ExpiresAt = expiresAt.Format(time.RFC3339)
+				status.Expired = !now.Before(expiresAt)
+				status.Remaining = remaining(expiresAt.Sub(now))
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses
+}
+
+// checkReachable checks every account of obj concurrently, and records
+// whether it is reachable in the statuses of its users
+func checkReachable(ctx context.Context, obj types.LitmuCtlConfig, statuses []accountStatus) {
+	ctx, cancel := context.WithTimeout(ctx, reachabilityTimeout)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		reachable = map[string]bool{}
+	)
+	for _, account := range obj.Accounts {
+		wg.Add(1)
+		go func(account types.Account) {
+			defer wg.Done()
+
+			transport, err := config.AccountTransport(account)
+			if err == nil {
+				err = apis.Status(ctx, account.Endpoint, transport)
+			}
+
+			mu.Lock()
+			reachable[account.Endpoint] = err == nil
+			mu.Unlock()
+		}(account)
+	}
+	wg.Wait()
+
+	for i := range statuses {
+		statuses[i].Reachable = reachable[statuses[i].Endpoint]
+	}
+}
+
+func printAccountsTable(statuses []accountStatus, withReachable bool, withToken bool) {
+	header := "CURRENT\tENDPOINT\tSERVER ENDPOINT\tUSERNAME\tEXPIRES AT\tREMAINING"
+	if withReachable {
+		header += "\tREACHABLE"
+	}
+	if withToken {
+		header += "\tTOKEN"
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	utils.White_B.Fprintln(writer, header)
+	for _, status := range statuses {
+		current := ""
+		if status.Current {
+			current = "*"
+		}
+		row := current + "\t" + status.Endpoint + "\t" + status.ServerEndpoint + "\t" + status.Username + "\t" + status.ExpiresAt + "\t" + status.Remaining
+		if withReachable {
+			row += "\t" + strconv.FormatBool(status.Reachable)
+		}
+		if withToken {
+			row += "\t" + status.Token
+		}
+		utils.White.Fprintln(writer, row)
+	}
+	writer.Flush()
+}
+
+// remaining formats the time left before a token expires
+func remaining(d time.Duration) string {
+	if d <= 0 {
+		return "expired"
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
 func init() {
 	ConfigCmd.AddCommand(getAccountsCmd)
+
+	getAccountsCmd.Flags().StringP("output", "o", "table", "Output format. One of:\njson|yaml|table")
+	getAccountsCmd.Flags().Bool("show-secrets", false, "Show the tokens of the users instead of redacting them")
 }
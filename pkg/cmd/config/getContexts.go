@@ -0,0 +1,58 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"os"
+	"text/tabwriter"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// getContextsCmd represents the getContexts command
+var getContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Short: "Display contexts defined in the litmusconfig",
+	Long:  `Display contexts defined in the litmusconfig`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		obj, err := config.YamltoObject(configFilePath)
+		utils.PrintError(err)
+
+		printContextsTable(obj)
+	},
+}
+
+func printContextsTable(obj types.LitmuCtlConfig) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	utils.White_B.Fprintln(writer, "CURRENT\tNAME\tENDPOINT\tUSERNAME\tPROJECT ID\tCHAOS INFRA ID\tKUBECONFIG\tKUBE CONTEXT")
+	for _, context := range obj.Contexts {
+		current := ""
+		if context.Name == obj.CurrentContext {
+			current = "*"
+		}
+		utils.White.Fprintln(writer, current+"\t"+context.Name+"\t"+context.Endpoint+"\t"+context.Username+"\t"+context.ProjectID+"\t"+context.InfraID+"\t"+context.Kubeconfig+"\t"+context.KubeContext)
+	}
+	writer.Flush()
+}
+
+func init() {
+	ConfigCmd.AddCommand(getContextsCmd)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// logoutCmd represents the logout command
+var logoutCmd = &cobra.Command{
+	Use: "logout",
+	Short: `Revokes the token of an account and removes the user from a litmusconfig file.
+		Examples(s)
+		#log out of the current account
+		litmusctl config logout
+
+		#log out of another account
+		litmusctl config logout --endpoint "" --username ""
+
+		#remove the user locally even if its token can't be revoked
+		litmusctl config logout --force
+		`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		err := config.ConfigSyntaxCheck(configFilePath)
+		utils.PrintError(err)
+
+		litmusconfig, err := config.YamltoObject(configFilePath)
+		utils.PrintError(err)
+
+		endpoint, err := cmd.Flags().GetString("endpoint")
+		utils.PrintError(err)
+		username, err := cmd.Flags().GetString("username")
+		utils.PrintError(err)
+
+		if endpoint == "" && username == "" {
+			endpoint, username = litmusconfig.CurrentAccount, litmusconfig.CurrentUser
+		}
+
+		user, ok := config.FindUser(litmusconfig, username, endpoint)
+		if !ok {
+			utils.Red.Println("\n⛔ Account not exists")
+			os.Exit(1)
+		}
+
+		force, err := cmd.Flags().GetBool("force")
+		utils.PrintError(err)
+
+		// The TLS and proxy settings of the account are needed to reach it
+		account, _ := config.FindAccount(litmusconfig, endpoint)
+		err = utils.ApplyAccountSettings(cmd, account)
+		utils.PrintError(err)
+
+		// An expired token is already unusable, so it only needs to be removed locally
+		err = apis.Logout(cmd.Context(), types.Credentials{Endpoint: endpoint, Username: username, Token: user.Token})
+		if err != nil && !errors.Is(err, apis.ErrUnauthenticated) {
+			if !force {
+				utils.Red.Println("\n❌ Failed to revoke the token, pass --force to remove the user anyway: " + err.Error())
+				os.Exit(utils.ExitCode(err))
+			}
+			utils.Red.Println("\n⚠️ Failed to revoke the token: " + err.Error())
+		}
+
+		err = config.DeleteAccount(endpoint, username, configFilePath)
+		utils.PrintError(err)
+
+		fmt.Printf("\n✅ Logged out '%s' of '%s'\n", username, endpoint)
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(logoutCmd)
+	logoutCmd.Flags().StringP("endpoint", "e", "", "Set the endpoint of the account, the current account by default")
+	logoutCmd.Flags().StringP("username", "u", "", "Set the username of the account, the current user by default")
+	logoutCmd.Flags().Bool("force", false, "Remove the user locally even if its token can't be revoked")
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Removes the users whose token has expired from a litmusconfig file",
+	Long:  `Removes the users whose token has expired from a litmusconfig file. Users with a credential source are kept, as their token is renewed automatically.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		err := config.ConfigSyntaxCheck(configFilePath)
+		utils.PrintError(err)
+
+		pruned, err := config.PruneExpiredUsers(configFilePath, time.Now())
+		utils.PrintError(err)
+
+		if len(pruned) == 0 {
+			fmt.Println("\nNo expired users found")
+			return
+		}
+		for _, user := range pruned {
+			fmt.Printf("Removed %s\n", user)
+		}
+		fmt.Printf("\n✅ Pruned %d expired users\n", len(pruned))
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(pruneCmd)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// refreshCmd represents the refresh command
+var refreshCmd = &cobra.Command{
+	Use: "refresh",
+	Short: `Renews the token of the current account.
+		Examples(s)
+		#renew the token using the credential source of the account, or prompt for the password
+		litmusctl config refresh
+		`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		err := config.ConfigSyntaxCheck(configFilePath)
+		utils.PrintError(err)
+
+		litmusconfig, err := config.YamltoObject(configFilePath)
+		utils.PrintError(err)
+
+		user, ok := config.FindUser(litmusconfig, litmusconfig.CurrentUser, litmusconfig.CurrentAccount)
+		if !ok {
+			utils.Red.Println("\n⛔ Current account is not set, run `litmusctl config set-account` first")
+			os.Exit(1)
+		}
+
+		// The TLS and proxy settings of the account are needed to reach it
+		account, _ := config.FindAccount(litmusconfig, litmusconfig.CurrentAccount)
+		err = utils.ApplyAccountSettings(cmd, account)
+		utils.PrintError(err)
+
+		nonInteractive, err := cmd.Flags().GetBool("non-interactive")
+		utils.PrintError(err)
+
+		if user.CredentialSource != nil || nonInteractive {
+			user, err = apis.Reauthenticate(cmd.Context(), litmusconfig.CurrentAccount, user)
+			utils.PrintError(err)
+		} else {
+			promptPassword := promptui.Prompt{
+				Label: "Password for " + user.Username,
+				Mask:  '*',
+			}
+			password, err := promptPassword.Run()
+			utils.PrintError(err)
+
+			user, err = apis.Login(cmd.Context(), types.AuthInput{
+				Endpoint: litmusconfig.CurrentAccount,
+				Username: user.Username,
+				Password: password,
+			}, user)
+			utils.PrintError(err)
+		}
+
+		err = config.UpdateUserToken(litmusconfig.CurrentAccount, user, configFilePath)
+		utils.PrintError(err)
+
+		utils.White_B.Printf("\n✅ Token of account.username/%s renewed", user.Username)
+		if expiresIn, err := strconv.ParseInt(user.ExpiresIn, 10, 64); err == nil {
+			utils.White_B.Printf(", it expires at %s", time.Unix(expiresIn, 0).Format(time.RFC1123))
+		}
+		utils.White_B.Println()
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(refreshCmd)
+	refreshCmd.Flags().BoolP("non-interactive", "n", false, "Fail instead of prompting for the password when the account has no credential source")
+}
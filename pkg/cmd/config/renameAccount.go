@@ -0,0 +1,65 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// renameAccountCmd represents the renameAccount command
+var renameAccountCmd = &cobra.Command{
+	Use: "rename-account",
+	Short: `Moves an account of a litmusconfig file to a new endpoint, keeping its users and contexts.
+		Examples(s)
+		#move an account after ChaosCenter changed its address
+		litmusctl config rename-account --endpoint "https://old.example.com" --new-endpoint "https://new.example.com"
+		`,
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		endpoint, err := cmd.Flags().GetString("endpoint")
+		utils.PrintError(err)
+		newEndpoint, err := cmd.Flags().GetString("new-endpoint")
+		utils.PrintError(err)
+		serverEndpoint, err := cmd.Flags().GetString("server-endpoint")
+		utils.PrintError(err)
+
+		if endpoint == "" || newEndpoint == "" {
+			utils.Red.Println("\n⛔ --endpoint and --new-endpoint are required")
+			os.Exit(1)
+		}
+
+		err = config.ConfigSyntaxCheck(configFilePath)
+		utils.PrintError(err)
+
+		err = config.RenameAccount(endpoint, newEndpoint, serverEndpoint, configFilePath)
+		utils.PrintError(err)
+
+		fmt.Printf("\n✅ Account '%s' renamed to '%s'\n", endpoint, newEndpoint)
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(renameAccountCmd)
+	renameAccountCmd.Flags().StringP("endpoint", "e", "", "Set the current endpoint of the account")
+	renameAccountCmd.Flags().String("new-endpoint", "", "Set the new endpoint of the account")
+	renameAccountCmd.Flags().String("server-endpoint", "", "Set the new server endpoint of the account, unchanged by default")
+}
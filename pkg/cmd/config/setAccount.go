@@ -22,11 +22,9 @@ import (
 	"net/url"
 	"os"
 	"strings"
-	"time"
 
 	infra "github.com/litmuschaos/litmusctl/pkg/apis/infrastructure"
 
-	"github.com/golang-jwt/jwt"
 	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/config"
 	"github.com/litmuschaos/litmusctl/pkg/types"
@@ -42,18 +40,25 @@ var setAccountCmd = &cobra.Command{
 		Examples(s)
 		#set a new account
 		litmusctl config set-account  --endpoint "" --password "" --username ""
+
+		#set a new account with an API token read from stdin
+		echo "$LITMUS_API_TOKEN" | litmusctl config set-account --non-interactive --endpoint "" --token -
 		`,
 	Run: func(cmd *cobra.Command, args []string) {
 		configFilePath := utils.GetLitmusConfigPath(cmd)
 
 		var (
-			authInput types.AuthInput
-			err       error
+			authInput        types.AuthInput
+			credentialSource types.CredentialSource
+			err              error
 		)
 
 		nonInteractive, err := cmd.Flags().GetBool("non-interactive")
 		utils.PrintError(err)
 
+		apiToken, apiTokenFile, err := readAPIToken(cmd)
+		utils.PrintError(err)
+
 		if nonInteractive {
 			authInput.Endpoint, err = cmd.Flags().GetString("endpoint")
 			utils.PrintError(err)
@@ -64,6 +69,17 @@ var setAccountCmd = &cobra.Command{
 			authInput.Password, err = cmd.Flags().GetString("password")
 			utils.PrintError(err)
 
+			credentialSource.PasswordEnv, err = cmd.Flags().GetString("password-env")
+			utils.PrintError(err)
+
+			credentialSource.PasswordFile, err = cmd.Flags().GetString("password-file")
+			utils.PrintError(err)
+
+			if authInput.Password == "" {
+				authInput.Password, err = apis.SourcePassword(credentialSource)
+				utils.PrintError(err)
+			}
+
 		} else {
 			// prompts for account details
 			promptEndpoint := promptui.Prompt{
@@ -72,20 +88,23 @@ var setAccountCmd = &cobra.Command{
 			authInput.Endpoint, err = promptEndpoint.Run()
 			utils.PrintError(err)
 
-			promptUsername := promptui.Prompt{
-				Label:   "Username [Default: " + utils.DefaultUsername + "]",
-				Default: utils.DefaultUsername,
-			}
-			authInput.Username, err = promptUsername.Run()
-			utils.PrintError(err)
+			// The API token identifies the user, no need to ask for a password
+			if apiToken == "" {
+				promptUsername := promptui.Prompt{
+					Label:   "Username [Default: " + utils.DefaultUsername + "]",
+					Default: utils.DefaultUsername,
+				}
+				authInput.Username, err = promptUsername.Run()
+				utils.PrintError(err)
 
-			promptPassword := promptui.Prompt{
-				Label: "Password",
-				Mask:  '*',
+				promptPassword := promptui.Prompt{
+					Label: "Password",
+					Mask:  '*',
+				}
+				pass, err := promptPassword.Run()
+				utils.PrintError(err)
+				authInput.Password = pass
 			}
-			pass, err := promptPassword.Run()
-			utils.PrintError(err)
-			authInput.Password = pass
 		}
 
 		// Validate and format the endpoint URL
@@ -110,7 +129,15 @@ var setAccountCmd = &cobra.Command{
 			authInput.Endpoint = newUrl.String()
 		}
 
-		if authInput.Endpoint != "" && authInput.Username != "" && authInput.Password != "" {
+		credentialStore, err := cmd.Flags().GetString("credential-store")
+		utils.PrintError(err)
+
+		if credentialStore != "" {
+			_, err = config.NewCredentialStore(credentialStore, configFilePath)
+			utils.PrintError(err)
+		}
+
+		if authInput.Endpoint != "" && (apiToken != "" || authInput.Username != "" && authInput.Password != "") {
 			exists := config.FileExists(configFilePath)
 			var lgt int
 			if exists {
@@ -118,29 +145,36 @@ var setAccountCmd = &cobra.Command{
 				utils.PrintError(err)
 			}
 
-			resp, err := apis.Auth(authInput)
+			// The connection settings are needed to log in, and stored with the account
+			account, err := connectionSettings(cmd, configFilePath, authInput.Endpoint)
+			utils.PrintError(err)
+			err = utils.ApplyAccountSettings(cmd, account)
 			utils.PrintError(err)
-			// Decoding token
-			token, _ := jwt.Parse(resp.AccessToken, nil)
-			if token == nil {
-				os.Exit(1)
-			}
-			claims, _ := token.Claims.(jwt.MapClaims)
 
-			var user = types.User{
-				ExpiresIn: fmt.Sprint(time.Now().Add(time.Second * time.Duration(resp.ExpiresIn)).Unix()),
-				Token:     resp.AccessToken,
-				Username:  claims["username"].(string),
+			var user types.User
+			if apiToken != "" {
+				// API tokens are validated against the auth server instead of logging in
+				user, err = apis.TokenLogin(cmd.Context(), authInput.Endpoint, apiToken)
+				utils.PrintError(err)
+				credentialSource = types.CredentialSource{APITokenFile: apiTokenFile}
+			} else {
+				user, err = apis.Login(cmd.Context(), authInput, types.User{})
+				utils.PrintError(err)
+			}
+			if credentialSource != (types.CredentialSource{}) {
+				user.CredentialSource = &credentialSource
 			}
 
+			uid, username, err := apis.TokenClaims(user.Token)
+			utils.PrintError(err)
+			user.Username = username
+
 			var users []types.User
 			users = append(users, user)
 
-			var account = types.Account{
-				Endpoint:       authInput.Endpoint,
-				Users:          users,
-				ServerEndpoint: authInput.Endpoint,
-			}
+			account.Endpoint = authInput.Endpoint
+			account.Users = users
+			account.ServerEndpoint = authInput.Endpoint
 
 			// If config file doesn't exist or length of the file is zero.
 			if !exists || lgt == 0 {
@@ -149,11 +183,12 @@ var setAccountCmd = &cobra.Command{
 				accounts = append(accounts, account)
 
 				var litmuCtlConfig = types.LitmuCtlConfig{
-					APIVersion:     "v1",
-					Kind:           "Config",
-					CurrentAccount: authInput.Endpoint,
-					CurrentUser:    claims["username"].(string),
-					Accounts:       accounts,
+					APIVersion:      config.CurrentAPIVersion,
+					Kind:            "Config",
+					CurrentAccount:  authInput.Endpoint,
+					CurrentUser:     username,
+					Accounts:        accounts,
+					CredentialStore: credentialStore,
 				}
 
 				err := config.CreateNewLitmusCtlConfig(configFilePath, litmuCtlConfig)
@@ -165,22 +200,24 @@ var setAccountCmd = &cobra.Command{
 				utils.PrintError(err)
 
 				var updateLitmusCtlConfig = types.UpdateLitmusCtlConfig{
-					Account:        account,
-					CurrentAccount: authInput.Endpoint,
-					CurrentUser:    claims["username"].(string),
-					ServerEndpoint: authInput.Endpoint,
+					Account:         account,
+					CurrentAccount:  authInput.Endpoint,
+					CurrentUser:     username,
+					ServerEndpoint:  authInput.Endpoint,
+					CredentialStore: credentialStore,
 				}
 
 				err = config.UpdateLitmusCtlConfig(updateLitmusCtlConfig, configFilePath)
 				utils.PrintError(err)
 			}
-			utils.White_B.Printf("\naccount.username/%s configured", claims["username"].(string))
+			utils.White_B.Printf("\naccount.username/%s configured", username)
 			credentials, err := utils.GetCredentials(cmd)
 			if err != nil {
 				utils.PrintError(err)
 			}
-			endpoint := credentials.Endpoint + utils.AuthAPIPath + "/get_user/" + claims["uid"].(string)
+			endpoint := credentials.Endpoint + utils.AuthAPIPath + "/get_user/" + uid
 			userResp, err := apis.SendRequest(
+				cmd.Context(),
 				apis.SendRequestParams{
 					Endpoint: endpoint,
 					Token:    "Bearer " + credentials.Token,
@@ -210,7 +247,7 @@ var setAccountCmd = &cobra.Command{
 			utils.Red.Println("\nError: some flags are missing. Run 'litmusctl config set-account --help' for usage. ")
 		}
 
-		serverResp, err := infra.GetServerVersion(authInput.Endpoint)
+		serverResp, err := infra.GetServerVersion(cmd.Context(), authInput.Endpoint)
 		var isCompatible bool
 		if err != nil {
 			utils.Red.Println("\nError: ", err)
@@ -244,4 +281,78 @@ func init() {
 	setAccountCmd.Flags().StringP("endpoint", "e", "", "Account endpoint. Mandatory")
 	setAccountCmd.Flags().StringP("username", "u", "", "Account username. Mandatory")
 	setAccountCmd.Flags().StringP("password", "p", "", "Account password. Mandatory")
+	setAccountCmd.Flags().String("password-env", "", "Name of an environment variable holding the account password, used to renew the token once it expires")
+	setAccountCmd.Flags().String("token", "", "ChaosCenter API token used instead of the username and password, or - to read it from stdin")
+	setAccountCmd.Flags().String("token-file", "", "Path of a file holding a ChaosCenter API token, read again whenever the token expires")
+	setAccountCmd.Flags().String("credential-store", "", "Where to keep the account tokens: file (default, encrypted when "+config.CredentialsPassphraseEnv+" is set) or keyring")
+	setAccountCmd.Flags().String("password-file", "", "Path of a file holding the account password, used to renew the token once it expires")
+	setAccountCmd.Flags().String("client-cert", "", "Client certificate used for mutual TLS with the portal, as a path or inline PEM")
+	setAccountCmd.Flags().String("client-key", "", "Client key used for mutual TLS with the portal, as a path or inline PEM")
+	setAccountCmd.Flags().String("proxy", "", "HTTP proxy URL used to reach the portal")
+}
+
+// readAPIToken returns the API token passed with --token, read from stdin when
+// the flag is "-", or read from the file passed with --token-file along with
+// the path of that file
+func readAPIToken(cmd *cobra.Command) (string, string, error) {
+	token, err := cmd.Flags().GetString("token")
+	if err != nil {
+		return "", "", err
+	}
+	tokenFile, err := cmd.Flags().GetString("token-file")
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case token == "-":
+		data, err := io.ReadAll(os.Stdin)
+		return strings.TrimSpace(string(data)), "", err
+	case token != "":
+		return token, "", nil
+	case tokenFile != "":
+		data, err := os.ReadFile(tokenFile)
+		return strings.TrimSpace(string(data)), tokenFile, err
+	}
+
+	return "", "", nil
+}
+
+// connectionSettings returns the TLS and proxy settings of the account at
+// endpoint, as stored in the config file and updated by the flags passed.
+// --skipSSL and --cacert are stored with the account when passed.
+func connectionSettings(cmd *cobra.Command, configFilePath string, endpoint string) (types.Account, error) {
+	var account types.Account
+	if config.FileExists(configFilePath) {
+		if length, err := config.GetFileLength(configFilePath); err == nil && length > 0 {
+			litmusconfig, err := config.YamltoObject(configFilePath)
+			if err != nil {
+				return account, err
+			}
+			account, _ = config.FindAccount(litmusconfig, endpoint)
+		}
+	}
+
+	var err error
+	if cmd.Flags().Changed("skipSSL") {
+		account.InsecureSkipVerify, err = cmd.Flags().GetBool("skipSSL")
+		if err != nil {
+			return account, err
+		}
+	}
+	for flag, value := range map[string]*string{
+		"cacert":      &account.CACert,
+		"client-cert": &account.ClientCert,
+		"client-key":  &account.ClientKey,
+		"proxy":       &account.Proxy,
+	} {
+		if cmd.Flags().Changed(flag) {
+			*value, err = cmd.Flags().GetString(flag)
+			if err != nil {
+				return account, err
+			}
+		}
+	}
+
+	return account, nil
 }
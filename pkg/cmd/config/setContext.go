@@ -0,0 +1,94 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// setContextCmd represents the setContext command
+var setContextCmd = &cobra.Command{
+	Use: "set-context <name>",
+	Short: `Creates or updates a named context in a litmusconfig file.
+		Examples(s)
+		#create a context for the current account with a default project and infra
+		litmusctl config set-context prod --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --chaos-infra-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c"
+
+		#point an existing context to another kubeconfig context
+		litmusctl config set-context prod --kube-context="prod-cluster"
+		`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		err := config.ConfigSyntaxCheck(configFilePath)
+		utils.PrintError(err)
+
+		litmusconfig, err := config.YamltoObject(configFilePath)
+		utils.PrintError(err)
+
+		// Flags update the existing context, a new one starts from the current account
+		context, exists := config.FindContext(litmusconfig, args[0])
+		if !exists {
+			context.Name = args[0]
+			context.Endpoint = litmusconfig.CurrentAccount
+			context.Username = litmusconfig.CurrentUser
+		}
+
+		for flag, value := range map[string]*string{
+			"endpoint":       &context.Endpoint,
+			"username":       &context.Username,
+			"project-id":     &context.ProjectID,
+			"chaos-infra-id": &context.InfraID,
+			"kubeconfig":     &context.Kubeconfig,
+			"kube-context":   &context.KubeContext,
+		} {
+			if cmd.Flags().Changed(flag) {
+				*value, err = cmd.Flags().GetString(flag)
+				utils.PrintError(err)
+			}
+		}
+
+		if context.Endpoint == "" || context.Username == "" {
+			utils.Red.Println("\n⛔ Current account is not set, pass --endpoint and --username")
+			os.Exit(1)
+		}
+
+		err = config.SetContext(context, configFilePath)
+		utils.PrintError(err)
+
+		if exists {
+			fmt.Printf("\n✅ Context '%s' updated\n", context.Name)
+		} else {
+			fmt.Printf("\n✅ Context '%s' created\n", context.Name)
+		}
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(setContextCmd)
+	setContextCmd.Flags().StringP("endpoint", "e", "", "Set the endpoint of the account, the current account by default")
+	setContextCmd.Flags().StringP("username", "u", "", "Set the username of the account, the current user by default")
+	setContextCmd.Flags().String("project-id", "", "Set the project ID used when --project-id is not passed")
+	setContextCmd.Flags().String("chaos-infra-id", "", "Set the Chaos Infrastructure ID used when --chaos-infra-id is not passed")
+	setContextCmd.Flags().String("kubeconfig", "", "Set the kubeconfig path used when --kubeconfig is not passed")
+	setContextCmd.Flags().String("kube-context", "", "Set the kubeconfig context, the current one of the kubeconfig by default")
+}
@@ -0,0 +1,47 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// useContextCmd represents the useContext command
+var useContextCmd = &cobra.Command{
+	Use:   "use-context <name>",
+	Short: "Sets the current-context, along with its account and user, in a litmusconfig file",
+	Long:  `Sets the current-context, along with its account and user, in a litmusconfig file`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		err := config.ConfigSyntaxCheck(configFilePath)
+		utils.PrintError(err)
+
+		err = config.UseContext(args[0], configFilePath)
+		utils.PrintError(err)
+
+		fmt.Printf("\n✅ Switched to context '%s'\n", args[0])
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(useContextCmd)
+}
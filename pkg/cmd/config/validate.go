@@ -0,0 +1,56 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Checks a litmusconfig file for problems",
+	Long: `Checks a litmusconfig file for unknown keys, unsupported versions, malformed endpoints,
+duplicate accounts, users and contexts, and a current account, user or context that doesn't exist.
+Each problem is reported with its line number. Config files of older versions are checked before they are migrated.`,
+	Annotations: map[string]string{config.SkipMigrationAnnotation: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		configFilePath := utils.GetLitmusConfigPath(cmd)
+
+		problems, err := config.Validate(configFilePath)
+		utils.PrintError(err)
+
+		if len(problems) == 0 {
+			fmt.Printf("\n✅ %s is valid\n", configFilePath)
+			return
+		}
+
+		utils.Red.Printf("\n⛔ %s has %d problems:\n", configFilePath, len(problems))
+		for _, problem := range problems {
+			fmt.Println("  " + problem.String())
+		}
+		os.Exit(1)
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(validateCmd)
+}
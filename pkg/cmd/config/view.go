@@ -16,38 +16,82 @@ limitations under the License.
 package config
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 
 	"os"
 
 	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 )
 
 // viewCmd represents the view command
 var viewCmd = &cobra.Command{
 	Use:   "view",
 	Short: "Display litmusconfig settings or a specified litmusconfig file",
-	Long:  `Display litmusconfig settings or a specified litmusconfig file. `,
+	Long: `Display litmusconfig settings or a specified litmusconfig file.
+Tokens, inline client keys and proxy passwords are redacted unless --show-secrets is passed.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		configFilePath := utils.GetLitmusConfigPath(cmd)
 
+		output, err := cmd.Flags().GetString("output")
+		utils.PrintError(err)
+		showSecrets, err := cmd.Flags().GetBool("show-secrets")
+		utils.PrintError(err)
+
 		exists := config.FileExists(configFilePath)
 		if !exists {
 			utils.Red.Println("File reading error open ", configFilePath, ": no such file or directory. Use --config or -c flag to point the configfile")
 			os.Exit(1)
 		}
 
-		data, err := os.ReadFile(configFilePath)
+		obj, err := readConfig(configFilePath, showSecrets)
 		utils.PrintError(err)
 
-		//Printing the config map
-		fmt.Print(string(data))
+		switch output {
+		case "yaml", "":
+			data, err := yaml.Marshal(obj)
+			utils.PrintError(err)
+
+			//Printing the config map
+			fmt.Print(string(data))
+		case "json":
+			utils.PrintInJsonFormat(obj)
+		case "table":
+			printAccountsTable(accountStatuses(obj, time.Now()), false, showSecrets)
+			if len(obj.Contexts) > 0 {
+				fmt.Println()
+				printContextsTable(obj)
+			}
+		default:
+			utils.PrintError(errors.New("unsupported output format " + output + ", use one of json|yaml|table"))
+		}
 	},
 }
 
+// readConfig reads the config file with its tokens when showSecrets is set,
+// and redacted otherwise. Redacted tokens are not loaded, so that the config
+// can be read when the credential store is locked.
+func readConfig(configFilePath string, showSecrets bool) (types.LitmuCtlConfig, error) {
+	if showSecrets {
+		return config.YamltoObject(configFilePath)
+	}
+
+	obj, err := config.ReadConfig(configFilePath)
+	if err != nil {
+		return types.LitmuCtlConfig{}, err
+	}
+	return config.Redact(obj), nil
+}
+
 func init() {
 	ConfigCmd.AddCommand(viewCmd)
+
+	viewCmd.Flags().StringP("output", "o", "yaml", "Output format. One of:\njson|yaml|table")
+	viewCmd.Flags().Bool("show-secrets", false, "Show tokens, inline client keys and proxy passwords instead of redacting them")
 }
@@ -52,16 +52,16 @@ var infraCmd = &cobra.Command{
 		nonInteractive, err := cmd.Flags().GetBool("non-interactive")
 		utils.PrintError(err)
 
-		kubeconfig, err := cmd.Flags().GetString("kubeconfig")
+		kubeconfig, err := utils.GetKubeconfig(cmd)
 		utils.PrintError(err)
 
 		var newInfra types.Infra
 
-		newInfra.ProjectId, err = cmd.Flags().GetString("project-id")
+		newInfra.ProjectId, err = utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if newInfra.ProjectId == "" {
-			userDetails, err := apis.GetProjectDetails(credentials)
+			userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 			utils.PrintError(err)
 
 			var (
@@ -81,7 +81,7 @@ var infraCmd = &cobra.Command{
 
 			if !projectExists {
 				utils.White_B.Print("Creating a random project...")
-				newInfra.ProjectId = infra_ops.CreateRandomProject(credentials)
+				newInfra.ProjectId = infra_ops.CreateRandomProject(cmd.Context(), credentials)
 			}
 		}
 
@@ -169,17 +169,17 @@ var infraCmd = &cobra.Command{
 
 			// Check if user has sufficient permissions based on mode
 			utils.White_B.Print("\n🏃 Running prerequisites check....")
-			infra_ops.ValidateSAPermissions(newInfra.Namespace, newInfra.Mode, &kubeconfig)
+			infra_ops.ValidateSAPermissions(cmd.Context(), newInfra.Namespace, newInfra.Mode, &kubeconfig)
 
 			// Check if infra already exists
-			isInfraExist, err, infraList := infra_ops.ValidateInfraNameExists(newInfra.InfraName, newInfra.ProjectId, credentials)
+			isInfraExist, err, infraList := infra_ops.ValidateInfraNameExists(cmd.Context(), newInfra.InfraName, newInfra.ProjectId, credentials)
 			utils.PrintError(err)
 
 			if isInfraExist {
 				infra_ops.PrintExistingInfra(infraList)
 				os.Exit(1)
 			}
-			envIDs, err := environment.ListChaosEnvironments(newInfra.ProjectId, credentials)
+			envIDs, err := environment.ListChaosEnvironments(cmd.Context(), newInfra.ProjectId, credentials)
 			utils.PrintError(err)
 
 			// Check if Environment exists
@@ -199,7 +199,7 @@ var infraCmd = &cobra.Command{
 			}
 
 		} else {
-			userDetails, err := apis.GetProjectDetails(credentials)
+			userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 			utils.PrintError(err)
 
 			if newInfra.ProjectId == "" {
@@ -211,21 +211,21 @@ var infraCmd = &cobra.Command{
 
 			// Check if user has sufficient permissions based on mode
 			utils.White_B.Print("\n🏃 Running prerequisites check....")
-			infra_ops.ValidateSAPermissions(newInfra.Namespace, modeType, &kubeconfig)
-			newInfra, err = infra_ops.GetInfraDetails(modeType, newInfra.ProjectId, credentials, &kubeconfig)
+			infra_ops.ValidateSAPermissions(cmd.Context(), newInfra.Namespace, modeType, &kubeconfig)
+			newInfra, err = infra_ops.GetInfraDetails(cmd.Context(), modeType, newInfra.ProjectId, credentials, &kubeconfig)
 			utils.PrintError(err)
 
-			newInfra.ServiceAccount, newInfra.SAExists = k8s.ValidSA(newInfra.Namespace, &kubeconfig)
+			newInfra.ServiceAccount, newInfra.SAExists = k8s.ValidSA(cmd.Context(), newInfra.Namespace, &kubeconfig)
 			newInfra.Mode = modeType
 		}
 
-		infra_ops.Summary(newInfra, &kubeconfig)
+		infra_ops.Summary(cmd.Context(), newInfra, &kubeconfig)
 
 		if !nonInteractive {
 			infra_ops.ConfirmInstallation()
 		}
 
-		infra, err := infrastructure.ConnectInfra(newInfra, credentials)
+		infra, err := infrastructure.ConnectInfra(cmd.Context(), newInfra, credentials)
 		if err != nil {
 			utils.Red.Println("\n❌ Chaos Infra connection failed: " + err.Error() + "\n")
 			os.Exit(1)
@@ -242,7 +242,7 @@ var infraCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		yamlOutput, err := k8s.ApplyManifest([]byte(infra.Data.RegisterInfraDetails.Manifest), kubeconfig)
+		yamlOutput, err := k8s.ApplyManifest(cmd.Context(), []byte(infra.Data.RegisterInfraDetails.Manifest), kubeconfig)
 		if err != nil {
 			utils.Red.Println("\n❌ failed to apply infra manifest, error: " + err.Error())
 			os.Exit(1)
@@ -252,7 +252,7 @@ var infraCmd = &cobra.Command{
 		utils.White.Print("\n", yamlOutput)
 
 		// Watch subscriber pod status
-		k8s.WatchPod(k8s.WatchPodParams{Namespace: newInfra.Namespace, Label: utils.ChaosInfraLabel}, &kubeconfig)
+		k8s.WatchPod(cmd.Context(), k8s.WatchPodParams{Namespace: newInfra.Namespace, Label: utils.ChaosInfraLabel}, &kubeconfig)
 
 		utils.White_B.Println("\n🚀 Chaos new infrastructure connection successful!! 🎉")
 	},
@@ -0,0 +1,71 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package create
+
+import (
+	"fmt"
+
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// apiTokenCmd represents the api-token command
+var apiTokenCmd = &cobra.Command{
+	Use: "api-token",
+	Short: `Create an API token for the current user
+	Example:
+	#create an API token valid for 90 days
+	litmusctl create api-token --name ci-pipeline --days 90
+
+	Note: The token is only displayed once, store it safely
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		name, err := cmd.Flags().GetString("name")
+		utils.PrintError(err)
+		if name == "" {
+			prompt := promptui.Prompt{
+				Label: "Enter a name for the API token",
+			}
+			name, err = prompt.Run()
+			utils.PrintError(err)
+		}
+
+		days, err := cmd.Flags().GetInt("days")
+		utils.PrintError(err)
+		if days <= 0 {
+			utils.Red.Println("⛔ --days must be a positive number of days")
+			return
+		}
+
+		token, err := apis.CreateAPIToken(cmd.Context(), credentials, name, days)
+		utils.PrintError(err)
+
+		utils.White_B.Fprintf(cmd.ErrOrStderr(), "API token '%s' created, valid for %d days. It won't be displayed again:\n", name, days)
+		fmt.Println(token)
+	},
+}
+
+func init() {
+	CreateCmd.AddCommand(apiTokenCmd)
+	apiTokenCmd.Flags().String("name", "", "Set the name of the API token")
+	apiTokenCmd.Flags().Int("days", 30, "Set the number of days the API token stays valid")
+}
@@ -44,7 +44,7 @@ var environmentCmd = &cobra.Command{
 
 		var newEnvironment models.CreateEnvironmentRequest
 
-		pid, err := cmd.Flags().GetString("project-id")
+		pid, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for project ID
@@ -94,7 +94,7 @@ var environmentCmd = &cobra.Command{
 		}
 		newEnvironment.Type = models.EnvironmentType(envType)
 
-		envs, err := environment.ListChaosEnvironments(pid, credentials)
+		envs, err := environment.ListChaosEnvironments(cmd.Context(), pid, credentials)
 		if err != nil {
 			utils.PrintError(err)
 		}
@@ -119,7 +119,7 @@ var environmentCmd = &cobra.Command{
 		}
 
 		// Perform authorization
-		userDetails, err := apis.GetProjectDetails(credentials)
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 		if err != nil {
 			utils.PrintError(err)
 		}
@@ -140,7 +140,7 @@ var environmentCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		newEnv, err := environment.CreateEnvironment(pid, newEnvironment, credentials)
+		newEnv, err := environment.CreateEnvironment(cmd.Context(), pid, newEnvironment, credentials)
 		if err != nil {
 			utils.Red.Println("\n❌ Chaos Environment connection failed: " + err.Error() + "\n")
 			os.Exit(1)
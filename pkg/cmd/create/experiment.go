@@ -16,9 +16,9 @@ limitations under the License.
 package create
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmusctl/pkg/apis"
@@ -36,20 +36,53 @@ var experimentCmd = &cobra.Command{
 	#create a Chaos Experiment
 	litmusctl create chaos-experiment -f chaos-experiment.yaml --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --chaos-infra-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c"
 
+	#create a Chaos Experiment from a template, substituting its {{ .Values.path }} placeholders
+	litmusctl create chaos-experiment -f chaos-experiment.tmpl.yaml --values env/prod.yaml --set target.ns=checkout --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --chaos-infra-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c"
+
+	#print the rendered template without creating the Chaos Experiment
+	litmusctl create chaos-experiment -f chaos-experiment.tmpl.yaml --values env/prod.yaml --render-only
+
 	Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 
+		workflowManifest, err := cmd.Flags().GetString("file")
+		utils.PrintError(err)
+
+		// Render the manifest template first, so that --render-only needs
+		// neither an account nor a project
+		valuesFiles, err := cmd.Flags().GetStringArray("values")
+		utils.PrintError(err)
+		sets, err := cmd.Flags().GetStringArray("set")
+		utils.PrintError(err)
+		renderOnly, err := cmd.Flags().GetBool("render-only")
+		utils.PrintError(err)
+
+		if workflowManifest == "" {
+			utils.Red.Println("⛔ Pass the manifest of the Chaos Experiment with -f")
+			os.Exit(1)
+		}
+		values, err := utils.LoadValues(valuesFiles, sets)
+		utils.PrintError(err)
+		manifest, err := utils.ReadManifest(workflowManifest)
+		utils.PrintError(err)
+		manifest, err = utils.RenderManifest(manifest, values)
+		if err != nil {
+			utils.Red.Println("❌ Error rendering Chaos Experiment manifest: " + err.Error())
+			os.Exit(1)
+		}
+		if renderOnly {
+			fmt.Print(string(manifest))
+			return
+		}
+
 		// Fetch user credentials
 		credentials, err := utils.GetCredentials(cmd)
 		utils.PrintError(err)
 
 		var chaosExperimentRequest models.SaveChaosExperimentRequest
 
-		workflowManifest, err := cmd.Flags().GetString("file")
-		utils.PrintError(err)
-
-		pid, err := cmd.Flags().GetString("project-id")
+		pid, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for project ID
@@ -63,7 +96,7 @@ var experimentCmd = &cobra.Command{
 			}
 		}
 
-		chaosExperimentRequest.InfraID, err = cmd.Flags().GetString("chaos-infra-id")
+		chaosExperimentRequest.InfraID, err = utils.GetInfraID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for Chaos Infra ID
@@ -85,7 +118,7 @@ var experimentCmd = &cobra.Command{
 		}
 
 		// Perform authorization
-		userDetails, err := apis.GetProjectDetails(credentials)
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 		utils.PrintError(err)
 		var editAccess = false
 		var project apis.Project
@@ -105,7 +138,7 @@ var experimentCmd = &cobra.Command{
 		}
 
 		// Parse experiment manifest and populate chaosExperimentInput
-		err = utils.ParseExperimentManifest(workflowManifest, &chaosExperimentRequest)
+		err = utils.ParseExperimentManifestData(manifest, &chaosExperimentRequest)
 		if err != nil {
 			utils.Red.Println("❌ Error parsing Chaos Experiment manifest: " + err.Error())
 			os.Exit(1)
@@ -113,25 +146,17 @@ var experimentCmd = &cobra.Command{
 		// Generate ExperimentID from ExperimentName
 		chaosExperimentRequest.ID = utils.GenerateNameID(chaosExperimentRequest.Name)
 		// Make API call
-		createExperiment, err := experiment.CreateExperiment(pid, chaosExperimentRequest, credentials)
+		_, err = experiment.CreateExperiment(cmd.Context(), pid, chaosExperimentRequest, credentials)
 		if err != nil {
-			if (createExperiment.Data == experiment.RunExperimentData{}) {
-				if strings.Contains(err.Error(), "multiple write errors") {
-					utils.Red.Println("\n❌ Chaos Experiment/" + chaosExperimentRequest.Name + " already exists")
-					os.Exit(1)
-				}
-				if strings.Contains(err.Error(), "no documents in result") {
-					utils.Red.Println("❌ The specified Project ID or Chaos Infrastructure ID doesn't exist.")
-					os.Exit(1)
-				}
-				if strings.Contains(err.Error(), "multiple run errors") {
-					utils.Red.Println("\n❌ Chaos Experiment already exists")
-					os.Exit(1)
-				}
-			} else {
-				utils.White_B.Print("\n❌ Chaos Experiment/" + chaosExperimentRequest.Name + " failed to be created: " + err.Error())
-				os.Exit(1)
+			switch {
+			case errors.Is(err, apis.ErrAlreadyExists):
+				utils.Red.Println("\n❌ Chaos Experiment/" + chaosExperimentRequest.Name + " already exists")
+			case errors.Is(err, apis.ErrNotFound):
+				utils.Red.Println("❌ The specified Project ID or Chaos Infrastructure ID doesn't exist.")
+			default:
+				utils.Red.Println("\n❌ Chaos Experiment/" + chaosExperimentRequest.Name + " failed to be created: " + err.Error())
 			}
+			os.Exit(utils.ExitCode(err))
 		}
 
 		//Successful creation
@@ -146,4 +171,7 @@ func init() {
 	experimentCmd.Flags().String("chaos-infra-id", "", "Set the chaos-infra-id to create Chaos Experiment for the particular Chaos Infrastructure. To see the Chaos Infrastructures, apply litmusctl get chaos-infra")
 	experimentCmd.Flags().StringP("file", "f", "", "The manifest file for the Chaos Experiment")
 	experimentCmd.Flags().StringP("description", "d", "", "The Description for the Chaos Experiment")
+	experimentCmd.Flags().StringArray("values", nil, "A values file for the {{ .Values.path }} placeholders of the manifest. Can be repeated, later files take precedence")
+	experimentCmd.Flags().StringArray("set", nil, "Set a value of the manifest template as path=value, overriding the values files. Values are read as YAML scalars, quote them to keep a string. Can be repeated")
+	experimentCmd.Flags().Bool("render-only", false, "Print the rendered manifest without creating the Chaos Experiment")
 }
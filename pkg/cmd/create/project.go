@@ -57,7 +57,7 @@ var projectCmd = &cobra.Command{
 			projectName = result
 		}
 		var response apis.CreateProjectResponse
-		response, err = apis.CreateProjectRequest(projectName, credentials)
+		response, err = apis.CreateProjectRequest(cmd.Context(), projectName, credentials)
 		if err != nil {
 			utils.PrintFormattedError("Error creating project", err)
 		} else {
@@ -0,0 +1,108 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package delete
+
+import (
+	"os"
+
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// apiTokenCmd represents the api-token command
+var apiTokenCmd = &cobra.Command{
+	Use: "api-token",
+	Short: `Revoke an API token of the current user
+	Example:
+	#revoke an API token
+	litmusctl delete api-token ci-pipeline
+
+	Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
+	`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		name := ""
+		if len(args) == 0 {
+			prompt := promptui.Prompt{
+				Label: "Enter the name of the API token",
+			}
+			name, err = prompt.Run()
+			utils.PrintError(err)
+		} else {
+			name = args[0]
+		}
+
+		if name == "" {
+			utils.Red.Println("⛔ API token name can't be empty!!")
+			os.Exit(1)
+		}
+
+		tokens, err := apis.ListAPITokens(cmd.Context(), credentials)
+		utils.PrintError(err)
+
+		var matches []apis.APIToken
+		for _, token := range tokens {
+			if token.Name == name {
+				matches = append(matches, token)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			utils.Red.Println("❌ API token '" + name + "' doesn't exist.")
+			os.Exit(apis.ExitCodeNotFound)
+		case 1:
+		default:
+			utils.Red.Println("❌ More than one API token is named '" + name + "', revoke them from ChaosCenter.")
+			os.Exit(1)
+		}
+
+		yes, err := cmd.Flags().GetBool("yes")
+		utils.PrintError(err)
+		if !yes {
+			prompt := promptui.Prompt{
+				Label:     "Are you sure you want to revoke this API token? (y/n)",
+				AllowEdit: true,
+			}
+			result, err := prompt.Run()
+			utils.PrintError(err)
+
+			if result != "y" {
+				utils.White_B.Println("\n❌ API token was not revoked.")
+				os.Exit(0)
+			}
+		}
+
+		err = apis.RevokeAPIToken(cmd.Context(), credentials, matches[0].Token)
+		if err != nil {
+			utils.Red.Println("\n❌ Error in revoking API token: ", err.Error())
+			os.Exit(utils.ExitCode(err))
+		}
+
+		utils.White_B.Println("\n🚀 API token '" + name + "' successfully revoked.")
+	},
+}
+
+func init() {
+	DeleteCmd.AddCommand(apiTokenCmd)
+
+	apiTokenCmd.Flags().BoolP("yes", "y", false, "Revoke the API token without asking for confirmation")
+}
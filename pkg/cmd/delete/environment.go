@@ -16,6 +16,7 @@ limitations under the License.
 package delete
 
 import (
+	"errors"
 	"os"
 
 	"github.com/litmuschaos/litmusctl/pkg/apis/environment"
@@ -24,7 +25,6 @@ import (
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
-	"strings"
 )
 
 // experimentCmd represents the Chaos Experiment command
@@ -44,7 +44,7 @@ Note: The default location of the config file is $HOME/.litmusconfig, and can be
 		credentials, err := utils.GetCredentials(cmd)
 		utils.PrintError(err)
 
-		projectID, err := cmd.Flags().GetString("project-id")
+		projectID, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		environmentID, err := cmd.Flags().GetString("environment-id")
@@ -83,7 +83,7 @@ Note: The default location of the config file is $HOME/.litmusconfig, and can be
 		}
 
 		// Perform authorization
-		userDetails, err := apis.GetProjectDetails(credentials)
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 		utils.PrintError(err)
 		var editAccess = false
 		var project apis.Project
@@ -102,11 +102,11 @@ Note: The default location of the config file is $HOME/.litmusconfig, and can be
 			os.Exit(1)
 		}
 
-		environmentGet, err := environment.GetChaosEnvironment(projectID, environmentID, credentials)
+		environmentGet, err := environment.GetChaosEnvironment(cmd.Context(), projectID, environmentID, credentials)
 		if err != nil {
-			if strings.Contains(err.Error(), "permission_denied") {
+			if errors.Is(err, apis.ErrPermissionDenied) {
 				utils.Red.Println("❌ You don't have enough permissions to delete an environment.")
-				os.Exit(1)
+				os.Exit(utils.ExitCode(err))
 			} else {
 				utils.PrintError(err)
 				os.Exit(1)
@@ -137,7 +137,7 @@ Note: The default location of the config file is $HOME/.litmusconfig, and can be
 		}
 
 		// Make API call
-		_, err = environment.DeleteEnvironment(projectID, environmentID, credentials)
+		_, err = environment.DeleteEnvironment(cmd.Context(), projectID, environmentID, credentials)
 		if err != nil {
 			utils.Red.Println("\n❌ Error in deleting Chaos Environment: ", err.Error())
 			os.Exit(1)
@@ -45,7 +45,7 @@ var experimentCmd = &cobra.Command{
 		utils.PrintError(err)
 
 		experimentID := ""
-		projectID, err := cmd.Flags().GetString("project-id")
+		projectID, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for project ID
@@ -83,7 +83,7 @@ var experimentCmd = &cobra.Command{
 		}
 
 		// Perform authorization
-		userDetails, err := apis.GetProjectDetails(credentials)
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 		utils.PrintError(err)
 		var editAccess = false
 		var project apis.Project
@@ -120,7 +120,7 @@ var experimentCmd = &cobra.Command{
 		}
 
 		// Make API call
-		deleteExperiment, err := experiment.DeleteChaosExperiment(projectID, &experimentID, credentials)
+		deleteExperiment, err := experiment.DeleteChaosExperiment(cmd.Context(), projectID, &experimentID, credentials)
 		if err != nil {
 			utils.Red.Println("\n❌ Error in deleting Chaos Experiment: ", err.Error())
 			os.Exit(1)
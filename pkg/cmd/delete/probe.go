@@ -39,7 +39,7 @@ var probeCmd = &cobra.Command{
 		credentials, err := utils.GetCredentials(cmd)
 		utils.PrintError(err)
 
-		projectID, err := cmd.Flags().GetString("project-id")
+		projectID, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for project ID
@@ -70,7 +70,7 @@ var probeCmd = &cobra.Command{
 		}
 
 		// Perform authorization
-		userDetails, err := apis.GetProjectDetails(credentials)
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 		utils.PrintError(err)
 		var editAccess = false
 		var project apis.Project
@@ -107,7 +107,7 @@ var probeCmd = &cobra.Command{
 		}
 
 		// Make API call
-		deleteProbe, err := probe.DeleteProbeRequest(projectID, probeID, credentials)
+		deleteProbe, err := probe.DeleteProbeRequest(cmd.Context(), projectID, probeID, credentials)
 		if err != nil {
 			utils.Red.Println("\n❌ Error in deleting Probe: ", err.Error())
 			os.Exit(1)
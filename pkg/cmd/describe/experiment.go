@@ -19,11 +19,12 @@ package describe
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 	"github.com/manifoldco/promptui"
@@ -42,7 +43,7 @@ var experimentCmd = &cobra.Command{
 
 		var describeExperimentRequest model.ListExperimentRequest
 
-		pid, err := cmd.Flags().GetString("project-id")
+		pid, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if pid == "" {
@@ -80,11 +81,11 @@ var experimentCmd = &cobra.Command{
 
 		describeExperimentRequest.ExperimentIDs = append(describeExperimentRequest.ExperimentIDs, &experimentID)
 
-		experiment, err := experiment.GetExperimentList(pid, describeExperimentRequest, credentials)
+		experiment, err := experiment.GetExperimentList(cmd.Context(), pid, describeExperimentRequest, credentials)
 		if err != nil {
-			if strings.Contains(err.Error(), "permission_denied") {
-				utils.Red.Println("❌ The specified Project ID doesn't exist.")
-				os.Exit(1)
+			if errors.Is(err, apis.ErrPermissionDenied) {
+				utils.Red.Println("❌ You don't have enough permissions to access this project, or the specified Project ID doesn't exist.")
+				os.Exit(utils.ExitCode(err))
 			} else {
 				utils.PrintError(err)
 				os.Exit(1)
@@ -40,7 +40,7 @@ var probeCmd = &cobra.Command{
 
 		var getProbeYAMLRequest model.GetProbeYAMLRequest
 
-		pid, err := cmd.Flags().GetString("project-id")
+		pid, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if pid == "" {
@@ -88,7 +88,7 @@ var probeCmd = &cobra.Command{
 			fmt.Printf("You chose %q\n", option)
 		}
 		getProbeYAMLRequest.Mode = model.Mode(probeMode)
-		getProbeYAML, err := apis.GetProbeYAMLRequest(pid, getProbeYAMLRequest, credentials)
+		getProbeYAML, err := apis.GetProbeYAMLRequest(cmd.Context(), pid, getProbeYAMLRequest, credentials)
 		if err != nil {
 			utils.PrintFormattedError("Failed to fetch probe YAML", err)
 			os.Exit(1)
@@ -0,0 +1,32 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package diff
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// DiffCmd represents the diff command
+var DiffCmd = &cobra.Command{
+	Use: "diff",
+	Short: `Compare resources of ChaosCenter with their local manifests
+		Examples:
+		#compare a Chaos Experiment with its manifest
+		litmusctl diff chaos-experiment -f chaos-experiment.yaml --project-id=""
+
+		Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
+	`,
+}
@@ -0,0 +1,186 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package diff
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// Like diff(1), diff exits with 1 when the manifests differ and with 2 or
+// the exit code of the error when the comparison fails
+const (
+	exitDifferent = 1
+	exitError     = 2
+)
+
+// experimentCmd represents the Chaos Experiment command
+var experimentCmd = &cobra.Command{
+	Use:   "chaos-experiment",
+	Short: "Compare a Chaos Experiment of the project with its manifest",
+	Long: `Compare a Chaos Experiment of the project with its manifest and print a unified diff.
+Both sides are normalized first: keys are sorted, JSON and YAML are equivalent, the suffix of names generated
+from generateName is left out, and so are empty fields and the labels added by ChaosCenter.
+Exits with 1 when they differ, so that drift can be detected in CI.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		exitOnError(err)
+
+		file, err := cmd.Flags().GetString("file")
+		exitOnError(err)
+		if file == "" {
+			exitOnError(errors.New("pass the manifest of the Chaos Experiment with -f"))
+		}
+
+		pid, err := utils.GetProjectID(cmd)
+		exitOnError(err)
+		if pid == "" {
+			exitOnError(errors.New("project ID can't be empty, pass it with --project-id"))
+		}
+
+		body, err := utils.ReadManifest(file)
+		exitOnError(err)
+
+		different := false
+		for _, document := range utils.SplitManifests(body) {
+			diff, err := diffExperiment(cmd.Context(), pid, file, document, credentials)
+			exitOnError(err)
+
+			if diff != "" {
+				different = true
+				printDiff(diff)
+			}
+		}
+
+		if different {
+			os.Exit(exitDifferent)
+		}
+	},
+}
+
+// diffExperiment returns the unified diff between the Chaos Experiment of
+// the project named in the manifest and the manifest, or "" when they match
+func diffExperiment(ctx context.Context, pid string, file string, manifest []byte, credentials types.Credentials) (string, error) {
+	name, generateName, err := utils.ManifestName(manifest)
+	if err != nil {
+		return "", err
+	}
+	if name == "" && generateName == "" {
+		return "", errors.New("no name or generateName provided for the Chaos Experiment in " + file)
+	}
+
+	existing, err := experiment.FindExperiment(ctx, pid, name, generateName, credentials)
+	if err != nil {
+		return "", err
+	}
+
+	liveName := name
+	live := []string{}
+	var desired interface{}
+	if existing == nil {
+		if liveName == "" {
+			liveName = generateName
+		}
+		desired, err = utils.NormalizeManifest(manifest)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		liveName = existing.Name
+		var liveObject interface{}
+		liveObject, desired, err = utils.AlignManifests([]byte(existing.ExperimentManifest), manifest)
+		if err != nil {
+			return "", err
+		}
+		live, err = yamlLines(liveObject)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	local, err := yamlLines(desired)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        live,
+		B:        local,
+		FromFile: "live/" + liveName,
+		ToFile:   "local/" + file,
+		Context:  3,
+	})
+}
+
+// yamlLines marshals the object to YAML, with sorted keys, split into lines
+func yamlLines(object interface{}) ([]string, error) {
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return difflib.SplitLines(string(data)), nil
+}
+
+func printDiff(diff string) {
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			utils.White_B.Print(line)
+		case strings.HasPrefix(line, "+"):
+			utils.Green.Print(line)
+		case strings.HasPrefix(line, "-"):
+			utils.Red.Print(line)
+		default:
+			utils.White.Print(line)
+		}
+	}
+}
+
+// exitOnError prints the error and exits with its exit code, or with
+// exitError for errors without one, which would be mistaken for a difference
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+
+	if errors.Is(err, context.Canceled) {
+		utils.PrintError(err)
+	}
+
+	utils.PrintFormattedError("Error", err)
+	code := utils.ExitCode(err)
+	if code == exitDifferent {
+		code = exitError
+	}
+	os.Exit(code)
+}
+
+func init() {
+	DiffCmd.AddCommand(experimentCmd)
+
+	experimentCmd.Flags().String("project-id", "", "Set the project-id to compare the Chaos Experiment of the particular project. To see the projects, apply litmusctl get projects")
+	experimentCmd.Flags().StringP("file", "f", "", "The manifest file or URL of the Chaos Experiment")
+}
@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/apitest"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+const localManifest = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pod-delete-
+  namespace: litmus
+spec:
+  entrypoint: pod-delete
+  templates:
+    - name: pod-delete
+      container:
+        image: litmuschaos/k8s:latest
+`
+
+// liveManifest is localManifest as stored by ChaosCenter
+const liveManifest = `{"apiVersion":"argoproj.io/v1alpha1","kind":"Workflow","metadata":{"name":"pod-delete-ab12c","namespace":"litmus",
+"creationTimestamp":null,"labels":{"infra_id":"i1"}},"spec":{"templates":[{"name":"pod-delete","container":{"image":"litmuschaos/k8s:latest",
+"resources":{}}}],"entrypoint":"pod-delete","arguments":{}},"status":{}}`
+
+func TestDiffExperiment(t *testing.T) {
+	tests := []struct {
+		name        string
+		experiments []*models.Experiment
+		manifest    string
+		wantLines   []string
+	}{
+		{
+			name:        "same experiment",
+			experiments: []*models.Experiment{{Name: "pod-delete-ab12c", ExperimentManifest: liveManifest}},
+			manifest:    localManifest,
+		},
+		{
+			name:        "changed image",
+			experiments: []*models.Experiment{{Name: "pod-delete-ab12c", ExperimentManifest: liveManifest}},
+			manifest:    strings.Replace(localManifest, "k8s:latest", "k8s:3.0.0", 1),
+			wantLines:   []string{"--- live/pod-delete-ab12c", "+++ local/experiment.yaml", "-      image: litmuschaos/k8s:latest", "+      image: litmuschaos/k8s:3.0.0"},
+		},
+		{
+			name:      "missing experiment",
+			manifest:  localManifest,
+			wantLines: []string{"--- live/pod-delete-", "+kind: Workflow", "+  generateName: pod-delete-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apitest.NewServer(t, apitest.Responses(map[string]interface{}{
+				"listExperiment": models.ListExperimentResponse{Experiments: tt.experiments},
+			}))
+			defer server.Close()
+
+			credentials := types.Credentials{Endpoint: server.URL, ServerEndpoint: server.URL, Token: "token"}
+			diff, err := diffExperiment(context.Background(), "project-1", "experiment.yaml", []byte(tt.manifest), credentials)
+			if err != nil {
+				t.Fatalf("diffExperiment() unexpected error = %v", err)
+			}
+
+			if len(tt.wantLines) == 0 && diff != "" {
+				t.Errorf("diffExperiment() = %s, want no difference", diff)
+			}
+			for _, line := range tt.wantLines {
+				if !strings.Contains(diff, line+"\n") {
+					t.Errorf("diffExperiment() misses %q in:\n%s", line, diff)
+				}
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package disconnect
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -44,7 +45,7 @@ var infraCmd = &cobra.Command{
 		credentials, err := utils.GetCredentials(cmd)
 		utils.PrintError(err)
 
-		projectID, err := cmd.Flags().GetString("project-id")
+		projectID, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for project ID
@@ -72,7 +73,7 @@ var infraCmd = &cobra.Command{
 		}
 
 		// Perform authorization
-		userDetails, err := apis.GetProjectDetails(credentials)
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 		utils.PrintError(err)
 		var editAccess = false
 		var project apis.Project
@@ -92,14 +93,14 @@ var infraCmd = &cobra.Command{
 		}
 
 		// Make API call
-		disconnectedInfra, err := infrastructure.DisconnectInfra(projectID, infraID, credentials)
+		disconnectedInfra, err := infrastructure.DisconnectInfra(cmd.Context(), projectID, infraID, credentials)
 		if err != nil {
-			if strings.Contains(err.Error(), "no documents in result") {
+			if errors.Is(err, apis.ErrNotFound) {
 				utils.Red.Println("❌  The specified Project ID or Chaos Infrastructure ID doesn't exist.")
-				os.Exit(1)
+				os.Exit(utils.ExitCode(err))
 			} else {
 				utils.Red.Println("\n❌ Error in disconnecting Chaos Infrastructure: ", err.Error())
-				os.Exit(1)
+				os.Exit(utils.ExitCode(err))
 			}
 		}
 
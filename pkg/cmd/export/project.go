@@ -0,0 +1,243 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/environment"
+	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
+	"github.com/litmuschaos/litmusctl/pkg/apis/infrastructure"
+	"github.com/litmuschaos/litmusctl/pkg/apis/probe"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// probeManifestMode is the mode of the probe manifests exported along with
+// the probes. It only sets the mode field of the manifest.
+const probeManifestMode = models.ModeSot
+
+var unsafeFileName = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// projectCmd represents the project command
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Export the resources of a project to a directory",
+	Long: `Export the Chaos Experiments, Probes, Chaos Environments and Chaos Infrastructures of a project to a directory,
+each in its own YAML file, along with an index.yaml. The directory can be imported again with litmusctl import project.
+Exported files are only readable by their owner, as experiments may hold secrets.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		pid, err := utils.GetProjectID(cmd)
+		utils.PrintError(err)
+		if pid == "" {
+			utils.Red.Println("⛔ Project ID can't be empty, pass it with --project-id")
+			os.Exit(1)
+		}
+
+		dir, err := cmd.Flags().GetString("output")
+		utils.PrintError(err)
+		if dir == "" {
+			utils.Red.Println("⛔ Pass the directory to export the project to with -o")
+			os.Exit(1)
+		}
+
+		index, err := exportProject(cmd.Context(), pid, dir, credentials)
+		utils.PrintError(err)
+
+		utils.White_B.Println("\n🚀 Exported " + strconv.Itoa(len(index.Experiments)) + " Chaos Experiments, " +
+			strconv.Itoa(len(index.Probes)) + " Probes, " + strconv.Itoa(len(index.Environments)) + " Chaos Environments and " +
+			strconv.Itoa(len(index.Infrastructures)) + " Chaos Infrastructures to " + dir + " 🎉")
+	},
+}
+
+// exportProject writes the resources of the project to dir and returns the
+// index written along with them
+func exportProject(ctx context.Context, pid string, dir string, credentials types.Credentials) (types.ProjectExport, error) {
+	index := types.ProjectExport{
+		APIVersion: types.ProjectExportAPIVersion,
+		Kind:       types.ProjectExportKind,
+		ProjectID:  pid,
+		Endpoint:   credentials.Endpoint,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	files := fileNames{}
+
+	environments, err := environment.ListChaosEnvironments(ctx, pid, credentials)
+	if err != nil {
+		return index, errors.New("failed to list the Chaos Environments: " + err.Error())
+	}
+	for _, env := range environments.Data.ListEnvironmentDetails.Environments {
+		file := files.name("environments", env.Name)
+		err = writeYAML(dir, file, models.CreateEnvironmentRequest{
+			EnvironmentID: env.EnvironmentID,
+			Name:          env.Name,
+			Type:          env.Type,
+			Description:   env.Description,
+			Tags:          env.Tags,
+		})
+		if err != nil {
+			return index, err
+		}
+		index.Environments = append(index.Environments, types.ExportedResource{ID: env.EnvironmentID, Name: env.Name, File: file})
+	}
+
+	infras, err := infrastructure.GetInfraList(ctx, credentials, pid, models.ListInfraRequest{})
+	if err != nil {
+		return index, errors.New("failed to list the Chaos Infrastructures: " + err.Error())
+	}
+	for _, infra := range infras.Data.ListInfraDetails.Infras {
+		metadata := types.InfraMetadata{
+			InfraID:       infra.InfraID,
+			Name:          infra.Name,
+			Tags:          infra.Tags,
+			EnvironmentID: infra.EnvironmentID,
+			PlatformName:  infra.PlatformName,
+			InfraScope:    infra.InfraScope,
+		}
+		if infra.Description != nil {
+			metadata.Description = *infra.Description
+		}
+		if infra.InfraNamespace != nil {
+			metadata.InfraNamespace = *infra.InfraNamespace
+		}
+		if infra.ServiceAccount != nil {
+			metadata.ServiceAccount = *infra.ServiceAccount
+		}
+
+		file := files.name("infrastructures", infra.Name)
+		if err := writeYAML(dir, file, metadata); err != nil {
+			return index, err
+		}
+		index.Infrastructures = append(index.Infrastructures, types.ExportedInfra{ID: infra.InfraID, Name: infra.Name, EnvironmentID: infra.EnvironmentID, File: file})
+	}
+
+	probes, err := probe.ListProbeRequest(ctx, pid, nil, credentials)
+	if err != nil {
+		return index, errors.New("failed to list the Probes: " + err.Error())
+	}
+	for _, p := range probes.Data.Probes {
+		details, err := probe.GetProbeRequest(ctx, pid, p.Name, credentials)
+		if err != nil {
+			return index, errors.New("failed to get Probe " + p.Name + ": " + err.Error())
+		}
+		// The probe is written as the request to create it again
+		var request models.ProbeRequest
+		data, err := json.Marshal(details.Data.GetProbe)
+		if err != nil {
+			return index, err
+		}
+		if err := json.Unmarshal(data, &request); err != nil {
+			return index, err
+		}
+
+		manifest, err := probe.GetProbeYAMLRequest(ctx, pid, models.GetProbeYAMLRequest{ProbeName: p.Name, Mode: probeManifestMode}, credentials)
+		if err != nil {
+			return index, errors.New("failed to get the manifest of Probe " + p.Name + ": " + err.Error())
+		}
+
+		file := files.name("probes", p.Name)
+		if err := writeYAML(dir, file, request); err != nil {
+			return index, err
+		}
+		manifestFile := files.name("probes", p.Name+".manifest")
+		if err := writeFile(dir, manifestFile, []byte(manifest.Data.GetProbeYAML)); err != nil {
+			return index, err
+		}
+		index.Probes = append(index.Probes, types.ExportedProbe{Name: p.Name, Type: string(p.Type), File: file, Manifest: manifestFile})
+	}
+
+	experiments, err := experiment.GetExperimentList(ctx, pid, models.ListExperimentRequest{}, credentials)
+	if err != nil {
+		return index, errors.New("failed to list the Chaos Experiments: " + err.Error())
+	}
+	for _, e := range experiments.Data.ListExperimentDetails.Experiments {
+		manifest, err := yaml.JSONToYAML([]byte(e.ExperimentManifest))
+		if err != nil {
+			return index, errors.New("failed to convert the manifest of Chaos Experiment " + e.Name + ": " + err.Error())
+		}
+
+		file := files.name("experiments", e.Name)
+		if err := writeFile(dir, file, manifest); err != nil {
+			return index, err
+		}
+		exported := types.ExportedExperiment{
+			ID:          e.ExperimentID,
+			Name:        e.Name,
+			Description: e.Description,
+			Tags:        e.Tags,
+			CronSyntax:  e.CronSyntax,
+			File:        file,
+		}
+		if e.Infra != nil {
+			exported.InfraID = e.Infra.InfraID
+		}
+		index.Experiments = append(index.Experiments, exported)
+	}
+
+	return index, writeYAML(dir, types.ProjectExportIndex, index)
+}
+
+// fileNames hands out a distinct file name to each exported resource
+type fileNames map[string]bool
+
+func (f fileNames) name(kind string, name string) string {
+	base := unsafeFileName.ReplaceAllString(name, "_")
+	file := filepath.Join(kind, base+".yaml")
+	for i := 2; f[file]; i++ {
+		file = filepath.Join(kind, base+"-"+strconv.Itoa(i)+".yaml")
+	}
+	f[file] = true
+
+	return file
+}
+
+func writeYAML(dir string, file string, object interface{}) error {
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(dir, file, data)
+}
+
+func writeFile(dir string, file string, data []byte) error {
+	path := filepath.Join(dir, file)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+func init() {
+	ExportCmd.AddCommand(projectCmd)
+
+	projectCmd.Flags().String("project-id", "", "Set the project-id to export the particular project. To see the projects, apply litmusctl get projects")
+	projectCmd.Flags().StringP("output", "o", "", "The directory to export the project to")
+}
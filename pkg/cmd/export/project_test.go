@@ -0,0 +1,85 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/apitest"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+func TestExportProject(t *testing.T) {
+	description := "staging cluster"
+	server := apitest.NewServer(t, apitest.Responses(map[string]interface{}{
+		"listEnvironments": models.ListEnvironmentResponse{Environments: []*models.Environment{
+			{EnvironmentID: "staging", Name: "staging", Type: models.EnvironmentTypeNonProd},
+		}},
+		"listInfras": models.ListInfraResponse{Infras: []*models.Infra{
+			{InfraID: "infra-1", Name: "staging/cluster", EnvironmentID: "staging", Description: &description, Token: "secret-token"},
+		}},
+		"listProbes":   []models.Probe{{Name: "check-frontend", Type: models.ProbeTypeHTTPProbe}},
+		"getProbe":     models.Probe{Name: "check-frontend", Type: models.ProbeTypeHTTPProbe, InfrastructureType: models.InfrastructureTypeKubernetes},
+		"getProbeYAML": "name: check-frontend\ntype: httpProbe\nmode: SOT\n",
+		"listExperiment": models.ListExperimentResponse{Experiments: []*models.Experiment{
+			{ExperimentID: "e1", Name: "pod-delete", ExperimentManifest: `{"kind":"Workflow","metadata":{"name":"pod-delete"}}`, Infra: &models.Infra{InfraID: "infra-1"}},
+			{ExperimentID: "e2", Name: "pod:delete", ExperimentManifest: `{"kind":"Workflow","metadata":{"name":"pod:delete"}}`, Infra: &models.Infra{InfraID: "infra-1"}},
+		}},
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	credentials := types.Credentials{Endpoint: server.URL, ServerEndpoint: server.URL, Token: "token"}
+	index, err := exportProject(context.Background(), "project-1", dir, credentials)
+	if err != nil {
+		t.Fatalf("exportProject() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, types.ProjectExportIndex))
+	if err != nil {
+		t.Fatalf("Failed to read the index: %v", err)
+	}
+	var written types.ProjectExport
+	if err := yaml.Unmarshal(data, &written); err != nil {
+		t.Fatalf("Invalid index: %v", err)
+	}
+	if written.Kind != types.ProjectExportKind || written.ProjectID != "project-1" {
+		t.Errorf("Index = %+v, want a project export of project-1", written)
+	}
+
+	if len(index.Experiments) != 2 || index.Experiments[0].File == index.Experiments[1].File {
+		t.Fatalf("Experiments = %+v, want 2 experiments in distinct files", index.Experiments)
+	}
+	if index.Experiments[0].InfraID != "infra-1" {
+		t.Errorf("Experiment infraID = %q, want infra-1", index.Experiments[0].InfraID)
+	}
+	if len(index.Infrastructures) != 1 || strings.Contains(index.Infrastructures[0].File, "/cluster") {
+		t.Errorf("Infrastructures = %+v, want the name sanitized", index.Infrastructures)
+	}
+	if len(index.Probes) != 1 || index.Probes[0].Manifest == "" {
+		t.Errorf("Probes = %+v, want the probe and its manifest", index.Probes)
+	}
+
+	for _, file := range []string{index.Experiments[0].File, index.Infrastructures[0].File, index.Probes[0].File, index.Probes[0].Manifest, index.Environments[0].File} {
+		info, err := os.Stat(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("Exported file %s: %v", file, err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("Exported file %s has mode %v, want 0600", file, info.Mode().Perm())
+		}
+	}
+
+	infra, _ := os.ReadFile(filepath.Join(dir, index.Infrastructures[0].File))
+	if strings.Contains(string(infra), "secret-token") {
+		t.Errorf("Exported infrastructure holds its token:\n%s", infra)
+	}
+	experiment, _ := os.ReadFile(filepath.Join(dir, index.Experiments[0].File))
+	if !strings.Contains(string(experiment), "kind: Workflow") {
+		t.Errorf("Exported experiment is not YAML:\n%s", experiment)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package get
+
+import (
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// apiTokensCmd represents the api-tokens command
+var apiTokensCmd = &cobra.Command{
+	Use:   "api-tokens",
+	Short: "Display list of API tokens of the current user",
+	Long:  `Display list of API tokens of the current user. The tokens themselves are never displayed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		outputFormat, _ := cmd.Flags().GetString("output")
+
+		tokens, err := apis.ListAPITokens(cmd.Context(), credentials)
+		utils.PrintError(err)
+
+		for i := range tokens {
+			tokens[i].Token = ""
+		}
+
+		switch outputFormat {
+		case "json":
+			utils.PrintInJsonFormat(tokens)
+
+		case "yaml":
+			utils.PrintInYamlFormat(tokens)
+
+		case "":
+			writer := tabwriter.NewWriter(os.Stdout, 8, 8, 8, '\t', tabwriter.AlignRight)
+			utils.White_B.Fprintln(writer, "NAME\tEXPIRES AT\tCREATED AT")
+			for _, token := range tokens {
+				expiresAt := time.Unix(token.ExpiresAt, 0)
+				createdAt := time.Unix(token.CreatedAt, 0)
+				utils.White.Fprintln(writer, token.Name+"\t"+expiresAt.String()+"\t"+createdAt.String()+"\t")
+			}
+			writer.Flush()
+		}
+	},
+}
+
+func init() {
+	GetCmd.AddCommand(apiTokensCmd)
+
+	apiTokensCmd.Flags().StringP("output", "o", "", "Output format. One of:\njson|yaml")
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package get
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -24,6 +25,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/apis/environment"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 	"github.com/manifoldco/promptui"
@@ -38,7 +40,7 @@ var GetChaosEnvironmentsCmd = &cobra.Command{
 		credentials, err := utils.GetCredentials(cmd)
 		utils.PrintError(err)
 
-		projectID, err := cmd.Flags().GetString("project-id")
+		projectID, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if projectID == "" {
@@ -55,11 +57,11 @@ var GetChaosEnvironmentsCmd = &cobra.Command{
 		utils.PrintError(err)
 
 		if environmentID == "" {
-			environmentList, err := environment.ListChaosEnvironments(projectID, credentials)
+			environmentList, err := environment.ListChaosEnvironments(cmd.Context(), projectID, credentials)
 			if err != nil {
-				if strings.Contains(err.Error(), "permission_denied") {
+				if errors.Is(err, apis.ErrPermissionDenied) {
 					utils.Red.Println("❌ You don't have enough permissions to access this resource.")
-					os.Exit(1)
+					os.Exit(utils.ExitCode(err))
 				} else {
 					utils.PrintError(err)
 					os.Exit(1)
@@ -117,11 +119,11 @@ var GetChaosEnvironmentsCmd = &cobra.Command{
 				page++
 			}
 		} else {
-			environmentGet, err := environment.GetChaosEnvironment(projectID, environmentID, credentials)
+			environmentGet, err := environment.GetChaosEnvironment(cmd.Context(), projectID, environmentID, credentials)
 			if err != nil {
-				if strings.Contains(err.Error(), "permission_denied") {
+				if errors.Is(err, apis.ErrPermissionDenied) {
 					utils.Red.Println("❌ You don't have enough permissions to access this resource.")
-					os.Exit(1)
+					os.Exit(utils.ExitCode(err))
 				} else {
 					utils.PrintError(err)
 					os.Exit(1)
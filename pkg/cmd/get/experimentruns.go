@@ -16,13 +16,14 @@ limitations under the License.
 package get
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
-	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
 
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
@@ -42,7 +43,7 @@ var experimentRunsCmd = &cobra.Command{
 		var listExperimentRunsRequest model.ListExperimentRunRequest
 		var projectID string
 
-		projectID, err = cmd.Flags().GetString("project-id")
+		projectID, err = utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if projectID == "" {
@@ -77,11 +78,11 @@ var experimentRunsCmd = &cobra.Command{
 			listExperimentRunsRequest.Pagination.Limit, _ = cmd.Flags().GetInt("count")
 		}
 
-		experimentRuns, err := experiment.GetExperimentRunsList(projectID, listExperimentRunsRequest, credentials)
+		experimentRuns, err := experiment.GetExperimentRunsList(cmd.Context(), projectID, listExperimentRunsRequest, credentials)
 		if err != nil {
-			if strings.Contains(err.Error(), "permission_denied") {
-				utils.Red.Println("❌ The specified Project ID doesn't exist.")
-				os.Exit(1)
+			if errors.Is(err, apis.ErrPermissionDenied) {
+				utils.Red.Println("❌ You don't have enough permissions to access this project, or the specified Project ID doesn't exist.")
+				os.Exit(utils.ExitCode(err))
 			} else {
 				utils.PrintError(err)
 				os.Exit(1)
@@ -16,15 +16,15 @@ limitations under the License.
 package get
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
 
-	"github.com/gorhill/cronexpr"
 	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 	"github.com/manifoldco/promptui"
@@ -42,7 +42,7 @@ var experimentsCmd = &cobra.Command{
 
 		var listExperimentRequest model.ListExperimentRequest
 		var pid string
-		pid, err = cmd.Flags().GetString("project-id")
+		pid, err = utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if pid == "" {
@@ -66,11 +66,11 @@ var experimentsCmd = &cobra.Command{
 		utils.PrintError(err)
 		listExperimentRequest.Filter.InfraName = &infraName
 
-		experiments, err := experiment.GetExperimentList(pid, listExperimentRequest, credentials)
+		experiments, err := experiment.GetExperimentList(cmd.Context(), pid, listExperimentRequest, credentials)
 		if err != nil {
-			if strings.Contains(err.Error(), "permission_denied") {
-				utils.Red.Println("❌ The specified Project ID doesn't exist.")
-				os.Exit(1)
+			if errors.Is(err, apis.ErrPermissionDenied) {
+				utils.Red.Println("❌ You don't have enough permissions to access this project, or the specified Project ID doesn't exist.")
+				os.Exit(utils.ExitCode(err))
 			} else {
 				utils.PrintError(err)
 				os.Exit(1)
@@ -119,7 +119,7 @@ var experimentsCmd = &cobra.Command{
 					if experiment.CronSyntax != "" {
 						utils.White.Fprintln(
 							writer,
-							experiment.ExperimentID+"\t"+experiment.Name+"\tCron Chaos Experiment\t"+cronexpr.MustParse(experiment.CronSyntax).Next(time.Now()).Format("January 2 2006, 03:04:05 pm")+"\t"+experiment.Infra.InfraID+"\t"+experiment.Infra.Name+"\t"+experiment.UpdatedBy.Username)
+							experiment.ExperimentID+"\t"+experiment.Name+"\tCron Chaos Experiment\t"+nextSchedule(experiment, time.Now())+"\t"+experiment.Infra.InfraID+"\t"+experiment.Infra.Name+"\t"+experiment.UpdatedBy.Username)
 					} else {
 						utils.White.Fprintln(
 							writer,
@@ -156,3 +156,23 @@ func init() {
 
 	experimentsCmd.Flags().StringP("output", "o", "", "Output format. One of:\njson|yaml")
 }
+
+// nextSchedule returns the next time a cron experiment runs, computed from
+// its cron syntax and the timezone of its manifest. Without a timezone the
+// experiment runs in the timezone of its infrastructure, which isn't known
+// here, so the time is computed and labelled in local time.
+func nextSchedule(experiment *model.Experiment, now time.Time) string {
+	schedule, err := utils.CronWorkflowSchedule([]byte(experiment.ExperimentManifest))
+	if err == nil && schedule.Suspended {
+		return "Suspended"
+	}
+
+	next, err := utils.NextCronTime(experiment.CronSyntax, schedule.Timezone, now)
+	if err != nil {
+		return "Invalid schedule"
+	}
+	if schedule.Timezone == "" {
+		return next.Format("January 2 2006, 03:04:05 pm MST") + " (local time)"
+	}
+	return next.Format("January 2 2006, 03:04:05 pm MST")
+}
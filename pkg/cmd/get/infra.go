@@ -16,12 +16,13 @@ limitations under the License.
 package get
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 	"text/tabwriter"
 
 	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/apis/infrastructure"
 
 	"github.com/litmuschaos/litmusctl/pkg/utils"
@@ -37,7 +38,7 @@ var InfraCmd = &cobra.Command{
 		credentials, err := utils.GetCredentials(cmd)
 		utils.PrintError(err)
 
-		projectID, err := cmd.Flags().GetString("project-id")
+		projectID, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if projectID == "" {
@@ -50,11 +51,11 @@ var InfraCmd = &cobra.Command{
 			}
 		}
 
-		infras, err := infrastructure.GetInfraList(credentials, projectID, models.ListInfraRequest{})
+		infras, err := infrastructure.GetInfraList(cmd.Context(), credentials, projectID, models.ListInfraRequest{})
 		if err != nil {
-			if strings.Contains(err.Error(), "permission_denied") {
-				utils.Red.Println("❌ you don't have enough permissions to access this project")
-				os.Exit(1)
+			if errors.Is(err, apis.ErrPermissionDenied) {
+				utils.Red.Println("❌ You don't have enough permissions to access this project.")
+				os.Exit(utils.ExitCode(err))
 			} else {
 				utils.PrintError(err)
 				os.Exit(1)
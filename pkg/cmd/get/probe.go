@@ -15,6 +15,8 @@ limitations under the License.
 package get
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -23,7 +25,8 @@ import (
 	"time"
 
 	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
-	apis "github.com/litmuschaos/litmusctl/pkg/apis/probe"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/apis/probe"
 	"github.com/litmuschaos/litmusctl/pkg/types"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 	"github.com/manifoldco/promptui"
@@ -39,7 +42,7 @@ var probesCmd = &cobra.Command{
 		utils.PrintError(err)
 
 		var projectID string
-		projectID, err = cmd.Flags().GetString("project-id")
+		projectID, err = utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if projectID == "" {
@@ -56,7 +59,7 @@ var probesCmd = &cobra.Command{
 		if ProbeID == "" {
 			getProbeList(projectID, cmd, credentials)
 		} else {
-			getProbeDetails(projectID, ProbeID, credentials)
+			getProbeDetails(cmd.Context(), projectID, ProbeID, credentials)
 
 		}
 
@@ -132,7 +135,7 @@ func getProbeList(projectID string, cmd *cobra.Command, credentials types.Creden
 		}
 	}
 
-	probes_get, _ := apis.ListProbeRequest(projectID, selectedItems, credentials)
+	probes_get, _ := probe.ListProbeRequest(cmd.Context(), projectID, selectedItems, credentials)
 	probes_data := probes_get.Data.Probes
 
 	itemsPerPage := 5
@@ -185,13 +188,13 @@ func getProbeList(projectID string, cmd *cobra.Command, credentials types.Creden
 	}
 
 }
-func getProbeDetails(projectID, ProbeID string, credentials types.Credentials) {
+func getProbeDetails(ctx context.Context, projectID, ProbeID string, credentials types.Credentials) {
 	//call the probe get endpoint to get the probes details
-	probeGet, err := apis.GetProbeRequest(projectID, ProbeID, credentials)
+	probeGet, err := probe.GetProbeRequest(ctx, projectID, ProbeID, credentials)
 	if err != nil {
-		if strings.Contains(err.Error(), "permission_denied") {
+		if errors.Is(err, apis.ErrPermissionDenied) {
 			utils.Red.Println("❌ You don't have enough permissions to access this resource.")
-			os.Exit(1)
+			os.Exit(utils.ExitCode(err))
 		} else {
 			utils.PrintError(err)
 			os.Exit(1)
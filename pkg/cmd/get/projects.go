@@ -37,7 +37,7 @@ var projectsCmd = &cobra.Command{
 
 		outputFormat, _ := cmd.Flags().GetString("output")
 
-		projects, err := apis.ListProject(credentials)
+		projects, err := apis.ListProject(cmd.Context(), credentials)
 		utils.PrintError(err)
 
 		switch outputFormat {
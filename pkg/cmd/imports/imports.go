@@ -0,0 +1,35 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package imports
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ImportCmd represents the import command
+var ImportCmd = &cobra.Command{
+	Use: "import",
+	Short: `Import resources to ChaosCenter from local files
+		Examples:
+		#import a project exported with litmusctl export project
+		litmusctl import project --project-id="d861b650-1549-4574-b2ba-ab754058dd04" -f backup/
+
+		#import a project, running its experiments on another Chaos Infrastructure
+		litmusctl import project --project-id="d861b650-1549-4574-b2ba-ab754058dd04" -f backup/ --infra-map="1c9c5801-8789-4ac9-bf5f-32649b707a5c=9f3b4c0e-2b7d-4bd1-9c43-3a8e0d2f1c11"
+
+		Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
+	`,
+}
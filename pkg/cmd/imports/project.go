@@ -0,0 +1,436 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/environment"
+	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
+	"github.com/litmuschaos/litmusctl/pkg/apis/infrastructure"
+	"github.com/litmuschaos/litmusctl/pkg/apis/probe"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// Actions planned for the imported resources
+const (
+	actionCreate    = "create"
+	actionUpdate    = "update"
+	actionUnchanged = "unchanged"
+	actionExists    = "exists"
+	actionMapped    = "mapped"
+	actionSkip      = "skip"
+)
+
+// importOptions holds how the resources of the export are matched with
+// those of the target project
+type importOptions struct {
+	// infraMap and environmentMap map exported IDs to IDs of the target project
+	infraMap       map[string]string
+	environmentMap map[string]string
+	// defaultInfraID is used for experiments whose infrastructure is not found
+	defaultInfraID string
+}
+
+// importAction is a step of an import, apply runs it
+type importAction struct {
+	kind    string
+	name    string
+	action  string
+	details string
+	apply   func(ctx context.Context) error
+}
+
+// projectCmd represents the project command
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Import the resources of a project exported with litmusctl export project",
+	Long: `Import the Chaos Experiments, Probes and Chaos Environments exported with litmusctl export project into a project.
+Chaos Infrastructures are not created, the experiments are assigned to the infrastructure given by --infra-map,
+or else to the infrastructure of the same ID or name in the project, or else to --chaos-infra-id.
+The infrastructures of a Chaos Environment mapped with --environment-map are matched by name in the environment it is mapped to.
+A summary of the changes is printed first and the import asks for a confirmation, unless --yes is set.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		pid, err := utils.GetProjectID(cmd)
+		utils.PrintError(err)
+		if pid == "" {
+			utils.Red.Println("⛔ Project ID can't be empty, pass it with --project-id")
+			os.Exit(1)
+		}
+
+		file, err := cmd.Flags().GetString("file")
+		utils.PrintError(err)
+		if file == "" {
+			utils.Red.Println("⛔ Pass the exported directory with -f")
+			os.Exit(1)
+		}
+
+		var options importOptions
+		options.infraMap, err = cmd.Flags().GetStringToString("infra-map")
+		utils.PrintError(err)
+		options.environmentMap, err = cmd.Flags().GetStringToString("environment-map")
+		utils.PrintError(err)
+		options.defaultInfraID, err = cmd.Flags().GetString("chaos-infra-id")
+		utils.PrintError(err)
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		utils.PrintError(err)
+		yes, err := cmd.Flags().GetBool("yes")
+		utils.PrintError(err)
+
+		dir, index, err := readIndex(file)
+		utils.PrintError(err)
+
+		actions, err := planImport(cmd.Context(), pid, dir, index, options, credentials)
+		utils.PrintError(err)
+
+		printPlan(actions)
+		if dryRun {
+			return
+		}
+
+		if !yes {
+			prompt := promptui.Prompt{
+				Label:     "Are you sure you want to import the project? (y/n)",
+				AllowEdit: true,
+			}
+			result, err := prompt.Run()
+			utils.PrintError(err)
+			if result != "y" {
+				utils.White_B.Println("\n❌ Import cancelled.")
+				os.Exit(0)
+			}
+		}
+
+		var failure error
+		for _, action := range actions {
+			if action.apply == nil {
+				continue
+			}
+			if err := action.apply(cmd.Context()); err != nil {
+				utils.Red.Println("❌ Failed to " + action.action + " " + action.kind + " " + action.name + ": " + err.Error())
+				failure = errors.Join(failure, err)
+				continue
+			}
+			utils.White_B.Println(action.kind + "/" + action.name + " " + action.action + "d")
+		}
+
+		if failure != nil {
+			os.Exit(utils.ExitCode(failure))
+		}
+		utils.White_B.Println("\n🚀 Project imported successfully 🎉")
+	},
+}
+
+// readIndex reads the index of an exported project, given either the
+// directory or the index file itself
+func readIndex(file string) (string, types.ProjectExport, error) {
+	var index types.ProjectExport
+
+	dir := file
+	if info, err := os.Stat(file); err == nil && !info.IsDir() {
+		dir = filepath.Dir(file)
+	} else {
+		file = filepath.Join(dir, types.ProjectExportIndex)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return dir, index, err
+	}
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return dir, index, errors.New("invalid index " + file + ": " + err.Error())
+	}
+	if index.Kind != types.ProjectExportKind {
+		return dir, index, errors.New(file + " is not an index of a project exported with litmusctl export project")
+	}
+
+	return dir, index, nil
+}
+
+// readExported reads a file referenced by the index, which has to stay
+// within the exported directory
+func readExported(dir string, file string) ([]byte, error) {
+	if !filepath.IsLocal(file) {
+		return nil, errors.New("file " + file + " is outside of the exported directory")
+	}
+
+	return os.ReadFile(filepath.Join(dir, file))
+}
+
+// planImport compares the exported resources with those of the project
+// and returns the actions importing them
+func planImport(ctx context.Context, pid string, dir string, index types.ProjectExport, options importOptions, credentials types.Credentials) ([]importAction, error) {
+	var actions []importAction
+
+	environments, err := environment.ListChaosEnvironments(ctx, pid, credentials)
+	if err != nil {
+		return nil, errors.New("failed to list the Chaos Environments: " + err.Error())
+	}
+	existingEnvironments := map[string]bool{}
+	for _, env := range environments.Data.ListEnvironmentDetails.Environments {
+		existingEnvironments[env.EnvironmentID] = true
+		existingEnvironments["name/"+env.Name] = true
+	}
+
+	for _, env := range index.Environments {
+		if target, ok := options.environmentMap[env.ID]; ok {
+			if !existingEnvironments[target] {
+				return nil, errors.New("Chaos Environment " + target + " of --environment-map not found in the project")
+			}
+			actions = append(actions, importAction{kind: "chaos-environment", name: env.Name, action: actionMapped, details: "to " + target})
+			continue
+		}
+		if existingEnvironments[env.ID] || existingEnvironments["name/"+env.Name] {
+			actions = append(actions, importAction{kind: "chaos-environment", name: env.Name, action: actionExists})
+			continue
+		}
+
+		data, err := readExported(dir, env.File)
+		if err != nil {
+			return nil, err
+		}
+		var request models.CreateEnvironmentRequest
+		if err := yaml.Unmarshal(data, &request); err != nil {
+			return nil, errors.New("invalid Chaos Environment " + env.File + ": " + err.Error())
+		}
+		actions = append(actions, importAction{
+			kind:   "chaos-environment",
+			name:   env.Name,
+			action: actionCreate,
+			apply: func(ctx context.Context) error {
+				_, err := environment.CreateEnvironment(ctx, pid, request, credentials)
+				return err
+			},
+		})
+	}
+
+	infras, err := infrastructure.GetInfraList(ctx, credentials, pid, models.ListInfraRequest{})
+	if err != nil {
+		return nil, errors.New("failed to list the Chaos Infrastructures: " + err.Error())
+	}
+	infraIDs := map[string]string{}
+	infraNames := map[string]string{}
+	// environmentInfras is keyed by environment ID and infrastructure name
+	environmentInfras := map[string]string{}
+	for _, infra := range infras.Data.ListInfraDetails.Infras {
+		infraIDs[infra.InfraID] = infra.InfraID
+		infraNames[infra.Name] = infra.InfraID
+		environmentInfras[infra.EnvironmentID+"/"+infra.Name] = infra.InfraID
+	}
+
+	// Resolve the infrastructure each exported infrastructure is imported as.
+	// The infrastructures of a mapped environment are looked up in the
+	// environment it is mapped to.
+	resolvedInfras := map[string]string{}
+	for _, infra := range index.Infrastructures {
+		action := importAction{kind: "chaos-infra", name: infra.Name, action: actionMapped}
+		targetEnvironment, environmentMapped := options.environmentMap[infra.EnvironmentID]
+		if target, ok := options.infraMap[infra.ID]; ok {
+			resolvedInfras[infra.ID] = target
+			action.details = "to " + target
+		} else if target, ok := infraIDs[infra.ID]; ok && !environmentMapped {
+			resolvedInfras[infra.ID] = target
+			action.action = actionExists
+		} else if target, ok := environmentInfras[targetEnvironment+"/"+infra.Name]; ok && environmentMapped {
+			resolvedInfras[infra.ID] = target
+			action.details = "to " + target + " in Chaos Environment " + targetEnvironment
+		} else if target, ok := infraNames[infra.Name]; ok && !environmentMapped {
+			resolvedInfras[infra.ID] = target
+			action.details = "to " + target + " by name"
+		} else if options.defaultInfraID != "" {
+			resolvedInfras[infra.ID] = options.defaultInfraID
+			action.details = "to " + options.defaultInfraID
+		} else if environmentMapped {
+			action.action = actionSkip
+			action.details = "not found in Chaos Environment " + targetEnvironment + ", pass --infra-map or --chaos-infra-id"
+		} else {
+			action.action = actionSkip
+			action.details = "not found, pass --infra-map or --chaos-infra-id"
+		}
+		actions = append(actions, action)
+	}
+
+	probes, err := probe.ListProbeRequest(ctx, pid, nil, credentials)
+	if err != nil {
+		return nil, errors.New("failed to list the Probes: " + err.Error())
+	}
+	existingProbes := map[string]bool{}
+	for _, p := range probes.Data.Probes {
+		existingProbes[p.Name] = true
+	}
+
+	for _, p := range index.Probes {
+		if existingProbes[p.Name] {
+			actions = append(actions, importAction{kind: "probe", name: p.Name, action: actionExists})
+			continue
+		}
+
+		data, err := readExported(dir, p.File)
+		if err != nil {
+			return nil, err
+		}
+		var request models.ProbeRequest
+		if err := yaml.Unmarshal(data, &request); err != nil {
+			return nil, errors.New("invalid Probe " + p.File + ": " + err.Error())
+		}
+		actions = append(actions, importAction{
+			kind:   "probe",
+			name:   p.Name,
+			action: actionCreate,
+			apply: func(ctx context.Context) error {
+				_, err := probe.AddProbeRequest(ctx, pid, request, credentials)
+				return err
+			},
+		})
+	}
+
+	experiments, err := experiment.GetExperimentList(ctx, pid, models.ListExperimentRequest{}, credentials)
+	if err != nil {
+		return nil, errors.New("failed to list the Chaos Experiments: " + err.Error())
+	}
+	existingExperiments := map[string]*models.Experiment{}
+	experimentIDs := map[string]bool{}
+	for _, e := range experiments.Data.ListExperimentDetails.Experiments {
+		existingExperiments[e.Name] = e
+		experimentIDs[e.ExperimentID] = true
+	}
+
+	for _, e := range index.Experiments {
+		infraID, ok := resolvedInfras[e.InfraID]
+		if !ok {
+			infraID, ok = options.infraMap[e.InfraID]
+		}
+		if !ok {
+			infraID, ok = infraIDs[e.InfraID]
+		}
+		if !ok && options.defaultInfraID != "" {
+			infraID, ok = options.defaultInfraID, true
+		}
+		if !ok {
+			actions = append(actions, importAction{kind: "chaos-experiment", name: e.Name, action: actionSkip, details: "Chaos Infrastructure " + e.InfraID + " not found"})
+			continue
+		}
+
+		data, err := readExported(dir, e.File)
+		if err != nil {
+			return nil, err
+		}
+		request := models.SaveChaosExperimentRequest{
+			Description: e.Description,
+			Tags:        e.Tags,
+			InfraID:     infraID,
+		}
+		if err := utils.ParseExperimentManifestData(data, &request); err != nil {
+			return nil, errors.New("invalid Chaos Experiment " + e.File + ": " + err.Error())
+		}
+		request.Manifest, err = setInfraLabels(request.Manifest, infraID)
+		if err != nil {
+			return nil, errors.New("invalid Chaos Experiment " + e.File + ": " + err.Error())
+		}
+
+		action := importAction{kind: "chaos-experiment", name: request.Name, details: "on " + infraID}
+		if existing, ok := existingExperiments[request.Name]; ok {
+			request.ID = existing.ExperimentID
+			same, err := utils.ManifestsEqual([]byte(existing.ExperimentManifest), []byte(request.Manifest))
+			if err != nil {
+				return nil, err
+			}
+			if same && request.Description == existing.Description && existing.Infra != nil && existing.Infra.InfraID == infraID {
+				action.action = actionUnchanged
+				actions = append(actions, action)
+				continue
+			}
+			action.action = actionUpdate
+		} else {
+			request.ID = e.ID
+			if request.ID == "" || experimentIDs[request.ID] {
+				request.ID = utils.GenerateNameID(request.Name)
+			}
+			action.action = actionCreate
+		}
+		action.apply = func(ctx context.Context) error {
+			_, err := experiment.SaveExperiment(ctx, pid, request, credentials)
+			return err
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// infraLabels are the labels ChaosCenter sets on experiment manifests with
+// the ID of the infrastructure they run on
+var infraLabels = []string{"infra_id", "workflows.argoproj.io/controller-instanceid"}
+
+// setInfraLabels returns the manifest with the infrastructure labels of the
+// workflow, and of the workflows created by a cron workflow, set to infraID
+func setInfraLabels(manifest string, infraID string) (string, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(manifest), &object); err != nil {
+		return "", err
+	}
+
+	metadata, _ := object["metadata"].(map[string]interface{})
+	spec, _ := object["spec"].(map[string]interface{})
+	workflowMetadata, _ := spec["workflowMetadata"].(map[string]interface{})
+	for _, meta := range []map[string]interface{}{metadata, workflowMetadata} {
+		labels, _ := meta["labels"].(map[string]interface{})
+		for _, label := range infraLabels {
+			if _, ok := labels[label]; ok {
+				labels[label] = infraID
+			}
+		}
+	}
+
+	data, err := json.Marshal(object)
+	return string(data), err
+}
+
+func printPlan(actions []importAction) {
+	writer := tabwriter.NewWriter(os.Stdout, 4, 8, 1, '\t', 0)
+	utils.White_B.Fprintln(writer, "KIND\tNAME\tACTION\tDETAILS")
+	for _, action := range actions {
+		utils.White.Fprintln(writer, fmt.Sprintf("%s\t%s\t%s\t%s", action.kind, action.name, action.action, action.details))
+	}
+	writer.Flush()
+}
+
+func init() {
+	ImportCmd.AddCommand(projectCmd)
+
+	projectCmd.Flags().String("project-id", "", "Set the project-id to import to the particular project. To see the projects, apply litmusctl get projects")
+	projectCmd.Flags().StringP("file", "f", "", "The directory exported with litmusctl export project, or its index.yaml")
+	projectCmd.Flags().StringToString("infra-map", nil, "Map exported Chaos Infrastructure IDs to those of the project, as old=new. Can be repeated")
+	projectCmd.Flags().StringToString("environment-map", nil, "Map exported Chaos Environment IDs to those of the project, as old=new. Infrastructures are matched by name in the mapped environment. Can be repeated")
+	projectCmd.Flags().String("chaos-infra-id", "", "Set the chaos-infra-id to run the Chaos Experiments whose Chaos Infrastructure is not found on")
+	projectCmd.Flags().Bool("dry-run", false, "Only print the summary of the import")
+	projectCmd.Flags().BoolP("yes", "y", false, "Import without asking for a confirmation")
+}
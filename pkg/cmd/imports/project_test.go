@@ -0,0 +1,252 @@
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/apitest"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+const testManifest = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: %s
+  namespace: litmus
+  labels:
+    infra_id: infra-1
+spec:
+  entrypoint: pod-delete
+  templates:
+    - name: pod-delete
+      container:
+        image: litmuschaos/k8s:latest
+`
+
+func writeExport(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestPlanImport(t *testing.T) {
+	dir := writeExport(t, map[string]string{
+		types.ProjectExportIndex: `apiVersion: v1
+kind: ProjectExport
+projectID: project-1
+environments:
+  - {id: staging, name: staging, file: environments/staging.yaml}
+  - {id: prod, name: prod, file: environments/prod.yaml}
+infrastructures:
+  - {id: infra-1, name: cluster, environmentID: staging, file: infrastructures/cluster.yaml}
+  - {id: infra-2, name: other, environmentID: prod, file: infrastructures/other.yaml}
+probes:
+  - {name: check-frontend, type: httpProbe, file: probes/check-frontend.yaml}
+  - {name: check-db, type: cmdProbe, file: probes/check-db.yaml}
+experiments:
+  - {id: e1, name: pod-delete, infraID: infra-1, file: experiments/pod-delete.yaml}
+  - {id: e2, name: pod-kill, infraID: infra-1, file: experiments/pod-kill.yaml}
+  - {id: e3, name: node-drain, infraID: infra-1, file: experiments/node-drain.yaml}
+  - {id: e4, name: cpu-hog, infraID: infra-2, file: experiments/cpu-hog.yaml}
+`,
+		"environments/staging.yaml":    "environmentID: staging\nname: staging\ntype: NON_PROD\n",
+		"environments/prod.yaml":       "environmentID: prod\nname: prod\ntype: PROD\n",
+		"probes/check-frontend.yaml":   "name: check-frontend\ntype: httpProbe\n",
+		"probes/check-db.yaml":         "name: check-db\ntype: cmdProbe\n",
+		"experiments/pod-delete.yaml":  strings.Replace(testManifest, "%s", "pod-delete", 1),
+		"experiments/pod-kill.yaml":    strings.Replace(testManifest, "%s", "pod-kill", 1),
+		"experiments/node-drain.yaml":  strings.Replace(testManifest, "%s", "node-drain", 1),
+		"experiments/cpu-hog.yaml":     strings.Replace(testManifest, "%s", "cpu-hog", 1),
+		"infrastructures/cluster.yaml": "infraID: infra-1\nname: cluster\n",
+		"infrastructures/other.yaml":   "infraID: infra-2\nname: other\n",
+	})
+
+	// The target project runs cluster as infra-9, already has the probe
+	// check-frontend, the experiment pod-delete and an experiment of ID e3
+	existingManifest := strings.Replace(strings.Replace(testManifest, "%s", "pod-delete", 1), "infra-1", "infra-9", 1)
+	server := apitest.NewServer(t, apitest.Responses(map[string]interface{}{
+		"listEnvironments": models.ListEnvironmentResponse{Environments: []*models.Environment{{EnvironmentID: "staging", Name: "staging"}}},
+		"listInfras":       models.ListInfraResponse{Infras: []*models.Infra{{InfraID: "infra-9", Name: "cluster"}}},
+		"listProbes":       []models.Probe{{Name: "check-frontend"}},
+		"listExperiment": models.ListExperimentResponse{Experiments: []*models.Experiment{
+			{ExperimentID: "pod_delete", Name: "pod-delete", ExperimentManifest: existingManifest, Infra: &models.Infra{InfraID: "infra-9"}},
+			{ExperimentID: "e3", Name: "other", ExperimentManifest: existingManifest, Infra: &models.Infra{InfraID: "infra-9"}},
+		}},
+	}))
+	defer server.Close()
+
+	exportDir, index, err := readIndex(filepath.Join(dir, types.ProjectExportIndex))
+	if err != nil {
+		t.Fatalf("readIndex() error = %v", err)
+	}
+	if exportDir != dir {
+		t.Errorf("readIndex() dir = %q, want %q", exportDir, dir)
+	}
+
+	credentials := types.Credentials{Endpoint: server.URL, ServerEndpoint: server.URL, Token: "token"}
+	actions, err := planImport(context.Background(), "project-2", dir, index, importOptions{}, credentials)
+	if err != nil {
+		t.Fatalf("planImport() error = %v", err)
+	}
+
+	want := map[string]string{
+		"chaos-environment/staging":   actionExists,
+		"chaos-environment/prod":      actionCreate,
+		"chaos-infra/cluster":         actionMapped,
+		"chaos-infra/other":           actionSkip,
+		"probe/check-frontend":        actionExists,
+		"probe/check-db":              actionCreate,
+		"chaos-experiment/pod-delete": actionUnchanged,
+		"chaos-experiment/pod-kill":   actionCreate,
+		"chaos-experiment/node-drain": actionCreate,
+		"chaos-experiment/cpu-hog":    actionSkip,
+	}
+	if len(actions) != len(want) {
+		t.Errorf("planImport() returned %d actions, want %d", len(actions), len(want))
+	}
+	for _, action := range actions {
+		key := action.kind + "/" + action.name
+		if want[key] != action.action {
+			t.Errorf("Action of %s = %q, want %q", key, action.action, want[key])
+		}
+		if (action.apply != nil) != (action.action == actionCreate || action.action == actionUpdate) {
+			t.Errorf("Action of %s = %q, apply set: %v", key, action.action, action.apply != nil)
+		}
+	}
+
+	// With the infrastructure mapped, cpu-hog is imported as well
+	options := importOptions{infraMap: map[string]string{"infra-2": "infra-9"}}
+	actions, err = planImport(context.Background(), "project-2", dir, index, options, credentials)
+	if err != nil {
+		t.Fatalf("planImport() error = %v", err)
+	}
+	for _, action := range actions {
+		if action.kind == "chaos-experiment" && action.name == "cpu-hog" && action.action != actionCreate {
+			t.Errorf("Action of cpu-hog with --infra-map = %q, want %q", action.action, actionCreate)
+		}
+	}
+}
+
+func TestPlanImportEnvironmentMap(t *testing.T) {
+	dir := writeExport(t, map[string]string{
+		types.ProjectExportIndex: `apiVersion: v1
+kind: ProjectExport
+projectID: project-1
+environments:
+  - {id: staging, name: staging, file: environments/staging.yaml}
+infrastructures:
+  - {id: infra-1, name: cluster, environmentID: staging, file: infrastructures/cluster.yaml}
+experiments:
+  - {id: e1, name: pod-delete, infraID: infra-1, file: experiments/pod-delete.yaml}
+`,
+		"environments/staging.yaml":    "environmentID: staging\nname: staging\ntype: NON_PROD\n",
+		"experiments/pod-delete.yaml":  strings.Replace(testManifest, "%s", "pod-delete", 1),
+		"infrastructures/cluster.yaml": "infraID: infra-1\nname: cluster\n",
+	})
+
+	// The target project has an infrastructure named cluster in both the
+	// staging and the prod environment
+	var saved []models.SaveChaosExperimentRequest
+	responses := apitest.Responses(map[string]interface{}{
+		"listEnvironments": models.ListEnvironmentResponse{Environments: []*models.Environment{
+			{EnvironmentID: "staging", Name: "staging"},
+			{EnvironmentID: "prod", Name: "prod"},
+		}},
+		"listInfras": models.ListInfraResponse{Infras: []*models.Infra{
+			{InfraID: "infra-s", Name: "cluster", EnvironmentID: "staging"},
+			{InfraID: "infra-p", Name: "cluster", EnvironmentID: "prod"},
+		}},
+		"listProbes":     []models.Probe{},
+		"listExperiment": models.ListExperimentResponse{},
+	})
+	responses["saveChaosExperiment"] = func(request apitest.Request) (interface{}, error) {
+		var save models.SaveChaosExperimentRequest
+		if err := request.Decode("request", &save); err != nil {
+			return nil, err
+		}
+		saved = append(saved, save)
+		return "experiment saved", nil
+	}
+	server := apitest.NewServer(t, responses)
+	defer server.Close()
+
+	_, index, err := readIndex(filepath.Join(dir, types.ProjectExportIndex))
+	if err != nil {
+		t.Fatalf("readIndex() error = %v", err)
+	}
+	credentials := types.Credentials{Endpoint: server.URL, ServerEndpoint: server.URL, Token: "token"}
+
+	options := importOptions{environmentMap: map[string]string{"staging": "missing"}}
+	if _, err := planImport(context.Background(), "project-2", dir, index, options, credentials); err == nil {
+		t.Error("planImport() with an unknown target environment succeeded, want an error")
+	}
+
+	options = importOptions{environmentMap: map[string]string{"staging": "prod"}}
+	actions, err := planImport(context.Background(), "project-2", dir, index, options, credentials)
+	if err != nil {
+		t.Fatalf("planImport() error = %v", err)
+	}
+	for _, action := range actions {
+		if action.apply == nil {
+			continue
+		}
+		if err := action.apply(context.Background()); err != nil {
+			t.Fatalf("Apply %s/%s error = %v", action.kind, action.name, err)
+		}
+	}
+
+	if len(saved) != 1 {
+		t.Fatalf("Saved %d Chaos Experiments, want 1", len(saved))
+	}
+	if saved[0].InfraID != "infra-p" {
+		t.Errorf("Saved InfraID = %q, want %q", saved[0].InfraID, "infra-p")
+	}
+	var manifest struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(saved[0].Manifest), &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if got := manifest.Metadata.Labels["infra_id"]; got != "infra-p" {
+		t.Errorf("Saved manifest label infra_id = %q, want %q", got, "infra-p")
+	}
+}
+
+func TestSetInfraLabels(t *testing.T) {
+	manifest := `{"kind":"CronWorkflow","metadata":{"name":"infra-1","labels":{"infra_id":"infra-1"}},"spec":{"workflowMetadata":{"labels":{"workflows.argoproj.io/controller-instanceid":"infra-1"}},"workflowSpec":{"arguments":{"parameters":[{"name":"infra","value":"infra-1"}]}}}}`
+	got, err := setInfraLabels(manifest, "infra-2")
+	if err != nil {
+		t.Fatalf("setInfraLabels() error = %v", err)
+	}
+	want := `{"kind":"CronWorkflow","metadata":{"labels":{"infra_id":"infra-2"},"name":"infra-1"},"spec":{"workflowMetadata":{"labels":{"workflows.argoproj.io/controller-instanceid":"infra-2"}},"workflowSpec":{"arguments":{"parameters":[{"name":"infra","value":"infra-1"}]}}}}`
+	if got != want {
+		t.Errorf("setInfraLabels() = %s, want %s", got, want)
+	}
+}
+
+func TestReadExported(t *testing.T) {
+	dir := writeExport(t, map[string]string{"experiments/a.yaml": "a"})
+	if _, err := readExported(dir, "experiments/a.yaml"); err != nil {
+		t.Errorf("readExported() error = %v", err)
+	}
+	for _, file := range []string{"../a.yaml", "/etc/passwd"} {
+		if _, err := readExported(dir, file); err == nil {
+			t.Errorf("readExported(%q) succeeded, want an error", file)
+		}
+	}
+}
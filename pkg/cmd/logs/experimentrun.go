@@ -0,0 +1,369 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package logs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
+	"github.com/litmuschaos/litmusctl/pkg/k8s"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// workflowLabel and nodeNameAnnotation are set by Argo on the pods of a workflow
+	workflowLabel      = "workflows.argoproj.io/workflow"
+	nodeNameAnnotation = "workflows.argoproj.io/node-name"
+	// chaosUIDLabel is set by the chaos-operator on the pods of a ChaosEngine
+	chaosUIDLabel = "chaosUID"
+	mainContainer = "main"
+)
+
+// pollInterval is the delay between two lookups of new pods with --follow
+var pollInterval = 2 * time.Second
+
+// logSource is a container whose logs are printed, prefixed by its step or fault
+type logSource struct {
+	prefix    string
+	namespace string
+	pod       string
+	container string
+	created   time.Time
+	// ready is false while the container hasn't started yet
+	ready bool
+}
+
+type logOptions struct {
+	follow bool
+	since  time.Duration
+	fault  string
+}
+
+// experimentRunCmd represents the chaos-experiment-run command
+var experimentRunCmd = &cobra.Command{
+	Use:   "chaos-experiment-run",
+	Short: "Print the logs of the pods of a Chaos Experiment run",
+	Long: `Print the logs of the pods of a Chaos Experiment run: the steps of its Argo workflow, and the chaos-runner and
+experiment pods of each fault. Each line is prefixed by its step or fault. The workflow and its namespace are found from
+ChaosCenter, and the pods are read from the cluster of the kubeconfig, so it has to point to the Chaos Infrastructure of the run.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		pid, err := utils.GetProjectID(cmd)
+		utils.PrintError(err)
+		if pid == "" {
+			utils.Red.Println("⛔ Project ID can't be empty, pass it with --project-id")
+			os.Exit(1)
+		}
+
+		runID, err := cmd.Flags().GetString("run-id")
+		utils.PrintError(err)
+		if runID == "" {
+			utils.Red.Println("⛔ Chaos Experiment run ID can't be empty, pass it with --run-id")
+			os.Exit(1)
+		}
+
+		var options logOptions
+		options.follow, err = cmd.Flags().GetBool("follow")
+		utils.PrintError(err)
+		options.since, err = cmd.Flags().GetDuration("since")
+		utils.PrintError(err)
+		options.fault, err = cmd.Flags().GetString("fault")
+		utils.PrintError(err)
+
+		kubeconfig, err := utils.GetKubeconfig(cmd)
+		utils.PrintError(err)
+		clientset, err := k8s.ClientSet(&kubeconfig)
+		utils.PrintError(err)
+
+		list := func(ctx context.Context) ([]logSource, bool, error) {
+			run, err := experiment.GetExperimentRun(ctx, pid, runID, credentials)
+			if err != nil {
+				return nil, false, err
+			}
+			sources, err := runLogSources(ctx, clientset, &run.Data.ExperimentRun, options.fault)
+			return sources, experiment.IsTerminalPhase(run.Data.ExperimentRun.Phase), err
+		}
+
+		err = streamLogs(cmd.Context(), clientset, list, options, os.Stdout)
+		if errors.Is(err, apis.ErrNotFound) {
+			utils.Red.Println("❌ The Chaos Experiment run " + runID + " doesn't exist in the project")
+			os.Exit(utils.ExitCode(err))
+		}
+		utils.PrintError(err)
+	},
+}
+
+// runLogSources returns the containers whose logs belong to the run, in the
+// order they were created. With fault set, only the steps and pods of this
+// fault are returned.
+func runLogSources(ctx context.Context, clientset kubernetes.Interface, run *model.ExperimentRun, fault string) ([]logSource, error) {
+	workflow, namespace, execution, err := runWorkflow(run)
+	if err != nil {
+		return nil, err
+	}
+
+	// Steps of the workflow injecting the fault
+	faultSteps := map[string]bool{}
+	for _, node := range execution.Nodes {
+		if node.ChaosData != nil && node.ChaosData.ExperimentName == fault {
+			faultSteps[node.Name] = true
+		}
+	}
+
+	var sources []logSource
+	pods, err := k8s.ListPods(ctx, clientset, namespace, workflowLabel+"="+workflow)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		step := stepName(pod, workflow)
+		if fault != "" && step != fault && !faultSteps[step] {
+			continue
+		}
+		sources = append(sources, newLogSource(step, pod, mainContainer))
+	}
+
+	for _, node := range execution.Nodes {
+		chaos := node.ChaosData
+		if chaos == nil || chaos.EngineUID == "" || (fault != "" && chaos.ExperimentName != fault && node.Name != fault) {
+			continue
+		}
+		chaosNamespace := chaos.Namespace
+		if chaosNamespace == "" {
+			chaosNamespace = namespace
+		}
+
+		pods, err := k8s.ListPods(ctx, clientset, chaosNamespace, chaosUIDLabel+"="+chaos.EngineUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods {
+			prefix := chaos.ExperimentName
+			if pod.Name == chaos.RunnerPod {
+				prefix += "/runner"
+			}
+			sources = append(sources, newLogSource(prefix, pod, ""))
+		}
+	}
+
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].created.Before(sources[j].created)
+	})
+	return sources, nil
+}
+
+// runWorkflow returns the name and namespace of the workflow of the run,
+// from its execution data or else from its manifest and infrastructure
+func runWorkflow(run *model.ExperimentRun) (string, string, types.ExecutionData, error) {
+	var execution types.ExecutionData
+	if run.ExecutionData != "" {
+		if err := json.Unmarshal([]byte(run.ExecutionData), &execution); err != nil {
+			return "", "", execution, errors.New("invalid execution data of the Chaos Experiment run: " + err.Error())
+		}
+	}
+
+	workflow, namespace := execution.Name, execution.Namespace
+	if workflow == "" || namespace == "" {
+		var manifest v1alpha1.Workflow
+		if err := json.Unmarshal([]byte(run.ExperimentManifest), &manifest); err == nil {
+			if workflow == "" {
+				workflow = manifest.Name
+			}
+			if namespace == "" {
+				namespace = manifest.Namespace
+			}
+		}
+	}
+	if namespace == "" && run.Infra != nil && run.Infra.InfraNamespace != nil {
+		namespace = *run.Infra.InfraNamespace
+	}
+
+	if workflow == "" || namespace == "" {
+		return "", "", execution, errors.New("the Chaos Experiment run " + run.ExperimentRunID + " hasn't started on its Chaos Infrastructure yet")
+	}
+	return workflow, namespace, execution, nil
+}
+
+// stepName returns the name of the workflow step run by the pod
+func stepName(pod v1.Pod, workflow string) string {
+	node, ok := pod.Annotations[nodeNameAnnotation]
+	if !ok {
+		return strings.TrimPrefix(pod.Name, workflow+"-")
+	}
+	// Node names are the path of the step from the workflow, as in workflow[0].step
+	if i := strings.LastIndex(node, "."); i >= 0 {
+		return node[i+1:]
+	}
+	return node
+}
+
+func newLogSource(prefix string, pod v1.Pod, container string) logSource {
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	ready := pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container && (status.State.Running != nil || status.State.Terminated != nil) {
+			ready = true
+		}
+	}
+
+	return logSource{
+		prefix:    prefix,
+		namespace: pod.Namespace,
+		pod:       pod.Name,
+		container: container,
+		created:   pod.CreationTimestamp.Time,
+		ready:     ready,
+	}
+}
+
+// streamLogs prints the logs of the sources returned by list. Without follow,
+// the logs of each container are printed once, one after the other. With
+// follow, list is called again until the run is finished, streaming the logs
+// of new containers as they start.
+func streamLogs(ctx context.Context, clientset kubernetes.Interface, list func(ctx context.Context) ([]logSource, bool, error), options logOptions, out io.Writer) error {
+	sources, finished, err := list(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer := &prefixWriter{out: out}
+	if !options.follow {
+		if len(sources) == 0 && options.fault != "" {
+			return errors.New("no pods found for the fault " + options.fault + " of the Chaos Experiment run")
+		}
+		if len(sources) == 0 {
+			return errors.New("no pods found for the Chaos Experiment run, check that the kubeconfig points to its Chaos Infrastructure")
+		}
+		for _, source := range sources {
+			if !source.ready {
+				continue
+			}
+			if err := printLogs(ctx, clientset, source, options, writer); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	var failure error
+	var failureMu sync.Mutex
+	started := map[string]bool{}
+	for {
+		for _, source := range sources {
+			key := source.namespace + "/" + source.pod + "/" + source.container
+			if !source.ready || started[key] {
+				continue
+			}
+			started[key] = true
+			wg.Add(1)
+			go func(source logSource) {
+				defer wg.Done()
+				if err := printLogs(ctx, clientset, source, options, writer); err != nil && !errors.Is(err, context.Canceled) {
+					failureMu.Lock()
+					failure = errors.Join(failure, err)
+					failureMu.Unlock()
+				}
+			}(source)
+		}
+
+		if finished {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+
+		sources, finished, err = list(ctx)
+		if err != nil {
+			wg.Wait()
+			return err
+		}
+	}
+
+	wg.Wait()
+	return failure
+}
+
+func printLogs(ctx context.Context, clientset kubernetes.Interface, source logSource, options logOptions, writer *prefixWriter) error {
+	stream, err := k8s.StreamPodLogs(ctx, clientset, k8s.PodLogsParams{
+		Namespace: source.namespace,
+		Pod:       source.pod,
+		Container: source.container,
+		Follow:    options.follow,
+		Since:     options.since,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the logs of pod %s: %w", source.pod, err)
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		writer.writeLine(source.prefix, scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// prefixWriter writes whole lines prefixed by their source, so that lines of
+// concurrent streams don't interleave
+type prefixWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (w *prefixWriter) writeLine(prefix string, line string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fmt.Fprintf(w.out, "[%s] %s\n", prefix, line)
+}
+
+func init() {
+	LogsCmd.AddCommand(experimentRunCmd)
+
+	experimentRunCmd.Flags().String("project-id", "", "Set the project-id of the Chaos Experiment run. To see the projects, apply litmusctl get projects")
+	experimentRunCmd.Flags().String("run-id", "", "Set the ID of the Chaos Experiment run. To see the runs, apply litmusctl get chaos-experiment-runs")
+	experimentRunCmd.Flags().BoolP("follow", "f", false, "Keep streaming the logs until the run finishes")
+	experimentRunCmd.Flags().Duration("since", 0, "Only print the logs newer than this duration, like 10m")
+	experimentRunCmd.Flags().String("fault", "", "Only print the logs of this fault, like pod-delete")
+	experimentRunCmd.Flags().StringP("kubeconfig", "k", "", "Set to pass kubeconfig file if it is not in the default location ($HOME/.kube/config)")
+}
@@ -0,0 +1,150 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testPod(name string, namespace string, minute int, labels map[string]string, annotations map[string]string, containers ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			Labels:            labels,
+			Annotations:       annotations,
+			CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 0, minute, 0, 0, time.UTC)),
+		},
+		Status: v1.PodStatus{Phase: v1.PodSucceeded},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: container})
+	}
+	return pod
+}
+
+func testExperimentRun(t *testing.T) *model.ExperimentRun {
+	execution, err := json.Marshal(types.ExecutionData{
+		Name:      "pod-delete-1700000000",
+		Namespace: "litmus",
+		Nodes: map[string]types.ExecutionNode{
+			"1": {Name: "install-chaos-faults"},
+			"2": {Name: "pod-delete", ChaosData: &types.ChaosData{EngineUID: "uid-1", Namespace: "apps", ExperimentName: "pod-delete", RunnerPod: "pod-delete-engine-runner"}},
+			"3": {Name: "cpu-hog", ChaosData: &types.ChaosData{EngineUID: "uid-2", Namespace: "apps", ExperimentName: "pod-cpu-hog"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &model.ExperimentRun{ExperimentRunID: "run-1", ExecutionData: string(execution), Phase: model.ExperimentRunStatusCompleted}
+}
+
+func testClientset() *fake.Clientset {
+	workflow := map[string]string{workflowLabel: "pod-delete-1700000000"}
+	return fake.NewSimpleClientset(
+		testPod("pod-delete-1700000000-1", "litmus", 0, workflow, map[string]string{nodeNameAnnotation: "pod-delete-1700000000.install-chaos-faults"}, "wait", "main"),
+		testPod("pod-delete-1700000000-2", "litmus", 1, workflow, map[string]string{nodeNameAnnotation: "pod-delete-1700000000.pod-delete"}, "wait", "main"),
+		testPod("pod-delete-engine-runner", "apps", 2, map[string]string{chaosUIDLabel: "uid-1"}, nil, "chaos-runner"),
+		testPod("pod-delete-abc12-x7k2p", "apps", 3, map[string]string{chaosUIDLabel: "uid-1"}, nil, "pod-delete"),
+		testPod("pod-cpu-hog-def34-9s8d7", "apps", 4, map[string]string{chaosUIDLabel: "uid-2"}, nil, "pod-cpu-hog"),
+		testPod("other-workflow-1", "litmus", 5, map[string]string{workflowLabel: "other"}, nil, "main"),
+	)
+}
+
+func TestRunLogSources(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault string
+		want  []logSource
+	}{
+		{
+			name: "all pods",
+			want: []logSource{
+				{prefix: "install-chaos-faults", namespace: "litmus", pod: "pod-delete-1700000000-1", container: "main"},
+				{prefix: "pod-delete", namespace: "litmus", pod: "pod-delete-1700000000-2", container: "main"},
+				{prefix: "pod-delete/runner", namespace: "apps", pod: "pod-delete-engine-runner", container: "chaos-runner"},
+				{prefix: "pod-delete", namespace: "apps", pod: "pod-delete-abc12-x7k2p", container: "pod-delete"},
+				{prefix: "pod-cpu-hog", namespace: "apps", pod: "pod-cpu-hog-def34-9s8d7", container: "pod-cpu-hog"},
+			},
+		},
+		{
+			name:  "single fault",
+			fault: "pod-cpu-hog",
+			want: []logSource{
+				{prefix: "pod-cpu-hog", namespace: "apps", pod: "pod-cpu-hog-def34-9s8d7", container: "pod-cpu-hog"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources, err := runLogSources(context.Background(), testClientset(), testExperimentRun(t), tt.fault)
+			if err != nil {
+				t.Fatalf("runLogSources() error = %v", err)
+			}
+			for i := range sources {
+				sources[i].created = time.Time{}
+				if !sources[i].ready {
+					t.Errorf("Source %s is not ready", sources[i].pod)
+				}
+				sources[i].ready = false
+			}
+			if !reflect.DeepEqual(sources, tt.want) {
+				t.Errorf("runLogSources() = %+v, want %+v", sources, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWorkflow(t *testing.T) {
+	namespace := "litmus"
+	run := &model.ExperimentRun{
+		ExperimentRunID:    "run-1",
+		ExperimentManifest: `{"kind":"Workflow","metadata":{"name":"pod-delete"}}`,
+		Infra:              &model.Infra{InfraNamespace: &namespace},
+	}
+	workflow, ns, _, err := runWorkflow(run)
+	if err != nil || workflow != "pod-delete" || ns != "litmus" {
+		t.Errorf("runWorkflow() = %q, %q, %v, want pod-delete, litmus", workflow, ns, err)
+	}
+
+	if _, _, _, err := runWorkflow(&model.ExperimentRun{ExperimentRunID: "run-1"}); err == nil {
+		t.Errorf("runWorkflow() of a run without workflow succeeded, want an error")
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	sources := []logSource{
+		{prefix: "install-chaos-faults", namespace: "litmus", pod: "pod-delete-1700000000-1", container: "main", ready: true},
+		{prefix: "pod-delete", namespace: "apps", pod: "pod-delete-abc12-x7k2p", container: "pod-delete", ready: true},
+		{prefix: "pod-cpu-hog", namespace: "apps", pod: "pod-cpu-hog-def34-9s8d7", container: "pod-cpu-hog"},
+	}
+	list := func(ctx context.Context) ([]logSource, bool, error) {
+		return sources, true, nil
+	}
+
+	for _, follow := range []bool{false, true} {
+		var out bytes.Buffer
+		if err := streamLogs(context.Background(), testClientset(), list, logOptions{follow: follow}, &out); err != nil {
+			t.Fatalf("streamLogs() error = %v", err)
+		}
+		// The fake clientset answers "fake logs" for every container
+		want := "[install-chaos-faults] fake logs\n[pod-delete] fake logs\n"
+		if follow {
+			if out.Len() != len(want) {
+				t.Errorf("streamLogs() with follow printed %q, want the lines of %q", out.String(), want)
+			}
+		} else if out.String() != want {
+			t.Errorf("streamLogs() printed %q, want %q", out.String(), want)
+		}
+	}
+}
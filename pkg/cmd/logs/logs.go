@@ -0,0 +1,35 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package logs
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// LogsCmd represents the logs command
+var LogsCmd = &cobra.Command{
+	Use: "logs",
+	Short: `Print the logs of ChaosCenter resources from the cluster
+		Examples:
+		#print the logs of a Chaos Experiment run
+		litmusctl logs chaos-experiment-run --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --run-id="3c1c3ab9-ef52-4c1e-87a3-8f0d26e7c2a5"
+
+		#follow the logs of a single fault of a running Chaos Experiment
+		litmusctl logs chaos-experiment-run --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --run-id="3c1c3ab9-ef52-4c1e-87a3-8f0d26e7c2a5" --fault=pod-delete --follow
+
+		Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
+	`,
+}
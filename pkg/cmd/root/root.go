@@ -16,14 +16,23 @@ limitations under the License.
 package rootCmd
 
 import (
-	"crypto/tls"
-	"crypto/x509"
+	"context"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
-
+	"os/signal"
+	"syscall"
+
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/cmd/apply"
+	"github.com/litmuschaos/litmusctl/pkg/cmd/diff"
+	"github.com/litmuschaos/litmusctl/pkg/cmd/export"
+	"github.com/litmuschaos/litmusctl/pkg/cmd/imports"
+	"github.com/litmuschaos/litmusctl/pkg/cmd/logs"
 	"github.com/litmuschaos/litmusctl/pkg/cmd/run"
 	"github.com/litmuschaos/litmusctl/pkg/cmd/save"
+	"github.com/litmuschaos/litmusctl/pkg/cmd/schedule"
+	"github.com/litmuschaos/litmusctl/pkg/cmd/stop"
 	"github.com/litmuschaos/litmusctl/pkg/cmd/update"
 
 	"github.com/litmuschaos/litmusctl/pkg/cmd/connect"
@@ -31,6 +40,7 @@ import (
 	"github.com/litmuschaos/litmusctl/pkg/cmd/describe"
 	"github.com/litmuschaos/litmusctl/pkg/cmd/disconnect"
 	"github.com/litmuschaos/litmusctl/pkg/cmd/upgrade"
+	"github.com/litmuschaos/litmusctl/pkg/cmd/validate"
 	"github.com/litmuschaos/litmusctl/pkg/cmd/version"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 
@@ -46,6 +56,12 @@ import (
 
 var cfgFile string
 
+var (
+	verbosity int
+	logFile   string
+	logFormat string
+)
+
 //var kubeconfig string
 
 // rootCmd represents the base command when called without any subcommands
@@ -53,17 +69,32 @@ var rootCmd = &cobra.Command{
 	Use:   "litmusctl",
 	Short: "Litmusctl controls the litmuschaos agent plane",
 	Long:  `Litmusctl controls the litmuschaos agent plane. ` + "\n" + ` Find more information at: https://github.com/litmuschaos/litmusctl`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Commands reading the config file as it is, such as config validate,
+		// see it before it is migrated
+		if _, ok := cmd.Annotations[config2.SkipMigrationAnnotation]; !ok {
+			migrateConfig()
+		}
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// The context passed to the commands is cancelled on Ctrl-C or SIGTERM, which
+// aborts any request in flight.
 func Execute() {
-	cobra.CheckErr(rootCmd.Execute())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cobra.CheckErr(rootCmd.ExecuteContext(ctx))
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	// Expired tokens are renewed through the credential source of the user
+	utils.Reauthenticate = apis.Reauthenticate
+
 	rootCmd.AddCommand(config.ConfigCmd)
 	rootCmd.AddCommand(create.CreateCmd)
 	rootCmd.AddCommand(get.GetCmd)
@@ -76,15 +107,31 @@ func init() {
 	rootCmd.AddCommand(save.SaveCmd)
 	rootCmd.AddCommand(run.RunCmd)
 	rootCmd.AddCommand(update.UpdateCmd)
+	rootCmd.AddCommand(apply.ApplyCmd)
+	rootCmd.AddCommand(diff.DiffCmd)
+	rootCmd.AddCommand(export.ExportCmd)
+	rootCmd.AddCommand(imports.ImportCmd)
+	rootCmd.AddCommand(logs.LogsCmd)
+	rootCmd.AddCommand(stop.StopCmd)
+	rootCmd.AddCommand(validate.ValidateCmd)
+	rootCmd.AddCommand(schedule.ScheduleCmd)
 
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.litmusctl)")
+	rootCmd.PersistentFlags().String("context", "", "context <name> , litmusctl context to use instead of the current-context of the config file")
 	//rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "kubeconfig file (default is $HOME/.kube/config")
 	rootCmd.PersistentFlags().BoolVar(&config2.SkipSSLVerify, "skipSSL", false, "skipSSL, litmusctl will skip ssl/tls verification while communicating with portal")
 	rootCmd.PersistentFlags().StringVar(&config2.CACert, "cacert", "", "cacert <path_to_crt_file> , custom ca certificate used for communicating with portal")
+	rootCmd.PersistentFlags().DurationVar(&config2.RequestTimeout, "request-timeout", 0, "request-timeout <duration> , maximum time to wait for a single request to the portal, e.g. 30s (0 means no timeout)")
+	rootCmd.PersistentFlags().IntVar(&config2.RetryCount, "retries", config2.RetryCount, "retries <count> , number of times a failed query to the portal is retried on connection errors or 429/502/503/504 responses")
+	rootCmd.PersistentFlags().DurationVar(&config2.RetryMaxWait, "retry-max-wait", config2.RetryMaxWait, "retry-max-wait <duration> , maximum wait between two retries")
+	rootCmd.PersistentFlags().BoolVar(&config2.RetryMutations, "retry-mutations", false, "retry-mutations, litmusctl will also retry mutations such as saving or running a chaos experiment")
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "verbose, trace every request sent to the portal on stderr, repeat (-vv) to also trace headers, variables and bodies")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "log-file <path> , write the request trace to this file instead of stderr, implies --verbose")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "log-format <format> , format of the request trace. One of:\ntext|json")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -104,18 +151,56 @@ func initConfig() {
 
 	viper.AutomaticEnv() // read in environment variables that match
 
-	if config2.SkipSSLVerify {
-		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	} else if config2.CACert != "" {
-		caCert, err := os.ReadFile(config2.CACert)
-		cobra.CheckErr(err)
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
-		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{RootCAs: caCertPool}
-	}
-
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	configureTracing()
+}
+
+// configureTracing enables the request trace asked for with --verbose or --log-file.
+// Passwords, tokens and Authorization headers are redacted from the trace.
+func configureTracing() {
+	if verbosity == 0 && logFile == "" {
+		return
+	}
+
+	out := io.Writer(os.Stderr)
+	if logFile != "" {
+		file, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		cobra.CheckErr(err)
+		out = file
+
+		if verbosity == 0 {
+			verbosity = 1
+		}
+	}
+
+	cobra.CheckErr(apis.ConfigureTracing(verbosity, out, logFormat))
+}
+
+// migrateConfig upgrades a litmusconfig file written by an older litmusctl.
+// Failures are only reported, the command then fails on the config file
+// itself if it needs it.
+func migrateConfig() {
+	configFilePath := cfgFile
+	if configFilePath == "" {
+		home, err := homedir.Dir()
+		cobra.CheckErr(err)
+
+		configFilePath = home + "/" + utils.DefaultFileName
+	}
+	if !config2.FileExists(configFilePath) {
+		return
+	}
+
+	from, err := config2.Migrate(configFilePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "⚠️ Failed to migrate the config file:", err)
+		return
+	}
+	if from != "" {
+		fmt.Fprintf(os.Stderr, "Migrated config file %s from %s to %s, the previous version is kept in %s.%s.bak with its tokens redacted\n", configFilePath, from, config2.CurrentAPIVersion, configFilePath, from)
+	}
 }
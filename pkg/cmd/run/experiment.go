@@ -16,9 +16,10 @@ limitations under the License.
 package run
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
+	"time"
 
 	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
@@ -34,6 +35,9 @@ var experimentCmd = &cobra.Command{
 	#Save a Chaos Experiment
 	litmusctl run chaos-experiment --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --experiment-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c"
 
+	#Run a Chaos Experiment and fail when its resiliency score is below 80
+	litmusctl run chaos-experiment --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --experiment-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c" --wait --timeout=20m --min-resiliency-score=80
+
 	Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -42,7 +46,7 @@ var experimentCmd = &cobra.Command{
 		credentials, err := utils.GetCredentials(cmd)
 		utils.PrintError(err)
 
-		pid, err := cmd.Flags().GetString("project-id")
+		pid, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for project ID
@@ -71,7 +75,7 @@ var experimentCmd = &cobra.Command{
 		}
 
 		// Perform authorization
-		userDetails, err := apis.GetProjectDetails(credentials)
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 		utils.PrintError(err)
 		var editAccess = false
 		var project apis.Project
@@ -90,27 +94,55 @@ var experimentCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		wait, err := cmd.Flags().GetBool("wait")
+		utils.PrintError(err)
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		utils.PrintError(err)
+		minScore, err := cmd.Flags().GetFloat64("min-resiliency-score")
+		utils.PrintError(err)
+		if !cmd.Flags().Changed("min-resiliency-score") {
+			minScore = -1
+		} else if minScore < 0 || minScore > 100 {
+			utils.Red.Println("⛔ --min-resiliency-score must be between 0 and 100")
+			os.Exit(1)
+		}
+
 		// Make API call
-		runExperiment, err := experiment.RunExperiment(pid, eid, credentials)
+		runResponse, err := experiment.RunExperiment(cmd.Context(), pid, eid, credentials)
 		if err != nil {
-			if (runExperiment.Data == experiment.RunExperimentData{}) {
-				if strings.Contains(err.Error(), "multiple run errors") {
-					utils.Red.Println("\n❌ Chaos Experiment already exists")
-					os.Exit(1)
-				}
-				if strings.Contains(err.Error(), "no documents in result") {
-					utils.Red.Println("❌ The specified Project ID or Chaos Infrastructure ID doesn't exist.")
-					os.Exit(1)
-				} else {
-					utils.White_B.Print("\n❌ Failed to run chaos experiment: " + err.Error())
-					os.Exit(1)
-				}
+			switch {
+			case errors.Is(err, apis.ErrAlreadyExists):
+				utils.Red.Println("\n❌ Chaos Experiment already exists")
+			case errors.Is(err, apis.ErrNotFound):
+				utils.Red.Println("❌ The specified Project ID or Chaos Infrastructure ID doesn't exist.")
+			default:
+				utils.Red.Println("\n❌ Failed to run chaos experiment: " + err.Error())
 			}
+			os.Exit(utils.ExitCode(err))
 		}
 
 		//Successful run
 		utils.White_B.Println("\n🚀 Chaos Experiment running successfully 🎉")
 
+		if !wait {
+			return
+		}
+
+		run, err := waitForRun(cmd.Context(), pid, eid, runResponse.Data.RunExperimentDetails.NotifyID, credentials, timeout, printProgress)
+		if errors.Is(err, errWaitTimeout) {
+			utils.Red.Println("\n⏰ Timed out after " + timeout.String() + " waiting for the Chaos Experiment run")
+			os.Exit(exitTimeout)
+		}
+		utils.PrintError(err)
+
+		code, message := runResult(run, minScore)
+		if code == exitPassed {
+			utils.White_B.Println("\n✅ " + message)
+		} else {
+			utils.Red.Println("\n❌ " + message)
+		}
+		os.Exit(code)
+
 	},
 }
 
@@ -119,4 +151,7 @@ func init() {
 
 	experimentCmd.Flags().String("project-id", "", "Set the project-id to create Chaos Experiment for the particular project. To see the projects, apply litmusctl get projects")
 	experimentCmd.Flags().String("experiment-id", "", "Set the environment-id to create Chaos Experiment for the particular Chaos Infrastructure. To see the Chaos Infrastructures, apply litmusctl get chaos-infra")
+	experimentCmd.Flags().Bool("wait", false, "Wait for the Chaos Experiment run to finish, and exit with a code telling its result")
+	experimentCmd.Flags().Duration("timeout", 30*time.Minute, "How long to wait for the Chaos Experiment run with --wait, 0 waits forever")
+	experimentCmd.Flags().Float64("min-resiliency-score", 0, "Fail with --wait when the resiliency score of the run is below this score, between 0 and 100")
 }
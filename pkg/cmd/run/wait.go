@@ -0,0 +1,166 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+)
+
+// Exit codes of run chaos-experiment --wait, so that pipelines can block on
+// the result of the run. Errors reaching ChaosCenter keep their own exit
+// code, see utils.ExitCode.
+const (
+	exitPassed     = 0
+	exitFailed     = 10
+	exitError      = 11
+	exitTimeout    = 12
+	exitLowScore   = 13
+	recentRunLimit = 15
+)
+
+// pollInterval is the delay between two polls of the run
+var pollInterval = 5 * time.Second
+
+var errWaitTimeout = errors.New("timed out waiting for the Chaos Experiment run")
+
+// waitForRun polls the runs of the experiment until the run triggered with
+// notifyID reaches a terminal phase. The run is looked up by its notifyID
+// until ChaosCenter assigns it a run ID, then by the run ID. progress is
+// called whenever the phase or the number of completed faults changes.
+func waitForRun(ctx context.Context, pid string, eid string, notifyID string, credentials types.Credentials, timeout time.Duration, progress func(*model.ExperimentRun)) (*model.ExperimentRun, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var runID string
+	var last string
+	for {
+		run, err := findRun(ctx, pid, eid, notifyID, runID, credentials)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && timeout > 0 {
+				return nil, errWaitTimeout
+			}
+			return nil, err
+		}
+
+		if run != nil {
+			runID = run.ExperimentRunID
+			state := string(run.Phase) + "/" + strconv.Itoa(completedFaults(run))
+			if state != last {
+				last = state
+				progress(run)
+			}
+			if experiment.IsTerminalPhase(run.Phase) {
+				return run, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && timeout > 0 {
+				return run, errWaitTimeout
+			}
+			return run, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// findRun returns the run of the given ID, or else the latest run of the
+// experiment triggered with notifyID. It returns nil while ChaosCenter hasn't
+// recorded the run yet.
+func findRun(ctx context.Context, pid string, eid string, notifyID string, runID string, credentials types.Credentials) (*model.ExperimentRun, error) {
+	request := model.ListExperimentRunRequest{ExperimentIDs: []*string{&eid}}
+	if runID != "" {
+		request.ExperimentRunIDs = []*string{&runID}
+	} else {
+		ascending := false
+		request.Sort = &model.ExperimentRunSortInput{Field: model.ExperimentSortingFieldTime, Ascending: &ascending}
+		request.Pagination = &model.Pagination{Limit: recentRunLimit}
+	}
+
+	runs, err := experiment.GetExperimentRunsList(ctx, pid, request, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, run := range runs.Data.ListExperimentRunDetails.ExperimentRuns {
+		if runID != "" && run.ExperimentRunID == runID {
+			return run, nil
+		}
+		if runID == "" && run.NotifyID != nil && *run.NotifyID == notifyID {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+func completedFaults(run *model.ExperimentRun) int {
+	completed := 0
+	if run.FaultsPassed != nil {
+		completed += *run.FaultsPassed
+	}
+	if run.FaultsFailed != nil {
+		completed += *run.FaultsFailed
+	}
+	return completed
+}
+
+// runResult returns the exit code and the message for a finished run. A
+// minScore below zero doesn't check the resiliency score.
+func runResult(run *model.ExperimentRun, minScore float64) (int, string) {
+	score := 0.0
+	if run.ResiliencyScore != nil {
+		score = *run.ResiliencyScore
+	}
+	scoreText := strconv.FormatFloat(score, 'f', 2, 64)
+
+	switch run.Phase {
+	case model.ExperimentRunStatusCompleted:
+		if minScore >= 0 && score < minScore {
+			return exitLowScore, "Resiliency score " + scoreText + " is below the minimum of " + strconv.FormatFloat(minScore, 'f', 2, 64)
+		}
+		return exitPassed, "Chaos Experiment run passed with a resiliency score of " + scoreText
+	case model.ExperimentRunStatusCompletedWithError:
+		return exitFailed, "Chaos Experiment run failed with a resiliency score of " + scoreText
+	case model.ExperimentRunStatusTimeout:
+		return exitTimeout, "Chaos Experiment run timed out"
+	default:
+		return exitError, "Chaos Experiment run ended with phase " + string(run.Phase)
+	}
+}
+
+func printProgress(run *model.ExperimentRun) {
+	message := fmt.Sprintf("⏳ %s", run.Phase)
+	if run.TotalFaults != nil && *run.TotalFaults > 0 {
+		message += fmt.Sprintf(": %d/%d faults completed", completedFaults(run), *run.TotalFaults)
+	}
+	if run.ExperimentRunID != "" {
+		message += " (run " + run.ExperimentRunID + ")"
+	}
+	utils.White.Println(message)
+}
@@ -0,0 +1,112 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/apitest"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+// fakeServer answers listExperimentRun with the next of the given runs on
+// each poll, and records the requests
+func fakeServer(t *testing.T, polls [][]*model.ExperimentRun, requests *[]model.ListExperimentRunRequest) *httptest.Server {
+	return apitest.NewServer(t, map[string]apitest.Handler{
+		"listExperimentRun": func(request apitest.Request) (interface{}, error) {
+			var listRequest model.ListExperimentRunRequest
+			if err := request.Decode("request", &listRequest); err != nil {
+				return nil, err
+			}
+			*requests = append(*requests, listRequest)
+
+			runs := polls[len(polls)-1]
+			if len(*requests) <= len(polls) {
+				runs = polls[len(*requests)-1]
+			}
+			return model.ListExperimentRunResponse{ExperimentRuns: runs}, nil
+		},
+	})
+}
+
+func testRun(runID string, notifyID string, phase model.ExperimentRunStatus, passed int) *model.ExperimentRun {
+	total := 2
+	score := 50.0 * float64(passed)
+	return &model.ExperimentRun{ExperimentRunID: runID, NotifyID: &notifyID, Phase: phase, FaultsPassed: &passed, TotalFaults: &total, ResiliencyScore: &score}
+}
+
+func TestWaitForRun(t *testing.T) {
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = 5 * time.Second }()
+
+	var requests []model.ListExperimentRunRequest
+	server := fakeServer(t, [][]*model.ExperimentRun{
+		{testRun("old-run", "notify-0", model.ExperimentRunStatusCompleted, 2)},
+		{testRun("run-1", "notify-1", model.ExperimentRunStatusRunning, 0), testRun("old-run", "notify-0", model.ExperimentRunStatusCompleted, 2)},
+		{testRun("run-1", "notify-1", model.ExperimentRunStatusRunning, 0)},
+		{testRun("run-1", "notify-1", model.ExperimentRunStatusRunning, 1)},
+		{testRun("run-1", "notify-1", model.ExperimentRunStatusCompleted, 2)},
+	}, &requests)
+	defer server.Close()
+
+	var progress []string
+	credentials := types.Credentials{Endpoint: server.URL, ServerEndpoint: server.URL, Token: "token"}
+	run, err := waitForRun(context.Background(), "project-1", "experiment-1", "notify-1", credentials, time.Minute, func(run *model.ExperimentRun) {
+		progress = append(progress, string(run.Phase))
+	})
+	if err != nil {
+		t.Fatalf("waitForRun() error = %v", err)
+	}
+	if run.ExperimentRunID != "run-1" || run.Phase != model.ExperimentRunStatusCompleted {
+		t.Errorf("waitForRun() = %+v, want run-1 completed", run)
+	}
+	if len(progress) != 3 {
+		t.Errorf("Progress reported %v, want each change reported once", progress)
+	}
+	if len(requests) != 5 || requests[0].ExperimentRunIDs != nil || requests[4].ExperimentRunIDs == nil || *requests[4].ExperimentRunIDs[0] != "run-1" {
+		t.Errorf("Requests = %+v, want the run looked up by notifyID then by run ID", requests)
+	}
+}
+
+func TestWaitForRunTimeout(t *testing.T) {
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = 5 * time.Second }()
+
+	var requests []model.ListExperimentRunRequest
+	server := fakeServer(t, [][]*model.ExperimentRun{{testRun("run-1", "notify-1", model.ExperimentRunStatusRunning, 0)}}, &requests)
+	defer server.Close()
+
+	credentials := types.Credentials{Endpoint: server.URL, ServerEndpoint: server.URL, Token: "token"}
+	_, err := waitForRun(context.Background(), "project-1", "experiment-1", "notify-1", credentials, 20*time.Millisecond, func(*model.ExperimentRun) {})
+	if !errors.Is(err, errWaitTimeout) {
+		t.Errorf("waitForRun() error = %v, want %v", err, errWaitTimeout)
+	}
+}
+
+func TestRunResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      *model.ExperimentRun
+		minScore float64
+		want     int
+	}{
+		{"passed", testRun("run-1", "", model.ExperimentRunStatusCompleted, 2), -1, exitPassed},
+		{"passed above the minimum score", testRun("run-1", "", model.ExperimentRunStatusCompleted, 2), 80, exitPassed},
+		{"score below the minimum", testRun("run-1", "", model.ExperimentRunStatusCompleted, 1), 80, exitLowScore},
+		{"failed", testRun("run-1", "", model.ExperimentRunStatusCompletedWithError, 1), -1, exitFailed},
+		{"error", testRun("run-1", "", model.ExperimentRunStatusError, 0), -1, exitError},
+		{"stopped", testRun("run-1", "", model.ExperimentRunStatusStopped, 0), -1, exitError},
+		{"timeout", testRun("run-1", "", model.ExperimentRunStatusTimeout, 0), -1, exitTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := runResult(tt.run, tt.minScore); got != tt.want {
+				t.Errorf("runResult() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
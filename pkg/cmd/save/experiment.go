@@ -16,9 +16,9 @@ limitations under the License.
 package save
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
 	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
@@ -50,7 +50,7 @@ var experimentCmd = &cobra.Command{
 		experimentManifest, err := cmd.Flags().GetString("file")
 		utils.PrintError(err)
 
-		pid, err := cmd.Flags().GetString("project-id")
+		pid, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for project ID
@@ -64,7 +64,7 @@ var experimentCmd = &cobra.Command{
 			}
 		}
 
-		chaosExperimentRequest.InfraID, err = cmd.Flags().GetString("chaos-infra-id")
+		chaosExperimentRequest.InfraID, err = utils.GetInfraID(cmd)
 		utils.PrintError(err)
 
 		// Handle blank input for Chaos Infra ID
@@ -86,7 +86,7 @@ var experimentCmd = &cobra.Command{
 		}
 
 		// Perform authorization
-		userDetails, err := apis.GetProjectDetails(credentials)
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
 		utils.PrintError(err)
 		var editAccess = false
 		var project apis.Project
@@ -115,22 +115,17 @@ var experimentCmd = &cobra.Command{
 		// Generate ExperimentID from the ExperimentName
 		chaosExperimentRequest.ID = utils.GenerateNameID(chaosExperimentRequest.Name)
 		// Make API call
-		saveExperiment, err := experiment.SaveExperiment(pid, chaosExperimentRequest, credentials)
+		_, err = experiment.SaveExperiment(cmd.Context(), pid, chaosExperimentRequest, credentials)
 		if err != nil {
-			if (saveExperiment.Data == experiment.SavedExperimentDetails{}) {
-
-				if strings.Contains(err.Error(), "multiple write errors") {
-					utils.Red.Println("\n❌ Chaos Experiment " + chaosExperimentRequest.Name + " already exists")
-					os.Exit(1)
-				}
-				if strings.Contains(err.Error(), "no documents in result") {
-					utils.Red.Println("❌ The specified Project ID or Chaos Infrastructure ID doesn't exist.")
-					os.Exit(1)
-				} else {
-					utils.White_B.Print("\n❌ Chaos Experiment " + chaosExperimentRequest.Name + " failed to be created: " + err.Error())
-					os.Exit(1)
-				}
+			switch {
+			case errors.Is(err, apis.ErrAlreadyExists):
+				utils.Red.Println("\n❌ Chaos Experiment " + chaosExperimentRequest.Name + " already exists")
+			case errors.Is(err, apis.ErrNotFound):
+				utils.Red.Println("❌ The specified Project ID or Chaos Infrastructure ID doesn't exist.")
+			default:
+				utils.Red.Println("\n❌ Chaos Experiment " + chaosExperimentRequest.Name + " failed to be saved: " + err.Error())
 			}
+			os.Exit(utils.ExitCode(err))
 		}
 
 		//Successful creation
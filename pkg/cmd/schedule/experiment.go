@@ -0,0 +1,268 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package schedule
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// experimentCmd represents the chaos-experiment command
+var experimentCmd = &cobra.Command{
+	Use:   "chaos-experiment",
+	Short: "Schedule, suspend or resume a Chaos Experiment",
+	Long: `Set the cron schedule and timezone of a Chaos Experiment with --cron and --timezone, turning it into a cron
+Chaos Experiment if needed, or suspend and resume the schedule of a cron Chaos Experiment with --suspend and --resume.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		pid, err := utils.GetProjectID(cmd)
+		utils.PrintError(err)
+		if pid == "" {
+			utils.Red.Println("⛔ Project ID can't be empty, pass it with --project-id")
+			os.Exit(1)
+		}
+
+		eid, err := cmd.Flags().GetString("experiment-id")
+		utils.PrintError(err)
+		if eid == "" {
+			utils.Red.Println("⛔ Chaos Experiment ID can't be empty, pass it with --experiment-id")
+			os.Exit(1)
+		}
+
+		cron, err := cmd.Flags().GetString("cron")
+		utils.PrintError(err)
+		timezone, err := cmd.Flags().GetString("timezone")
+		utils.PrintError(err)
+		suspend, err := cmd.Flags().GetBool("suspend")
+		utils.PrintError(err)
+		resume, err := cmd.Flags().GetBool("resume")
+		utils.PrintError(err)
+		if cron == "" && timezone == "" && !suspend && !resume {
+			utils.Red.Println("⛔ Nothing to change, pass --cron, --timezone, --suspend or --resume")
+			os.Exit(1)
+		}
+
+		if cron != "" {
+			if err := utils.ValidateCronSyntax(cron); err != nil {
+				utils.Red.Println("⛔ Invalid cron expression " + cron + ": " + err.Error())
+				os.Exit(1)
+			}
+		}
+		if timezone != "" {
+			if _, err := time.LoadLocation(timezone); err != nil {
+				utils.Red.Println("⛔ Invalid timezone " + timezone + ": " + err.Error())
+				os.Exit(1)
+			}
+		}
+
+		// Perform authorization
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
+		utils.PrintError(err)
+		var editAccess = false
+		var project apis.Project
+		for _, p := range userDetails.Data.Projects {
+			if p.ID == pid {
+				project = p
+			}
+		}
+		for _, member := range project.Members {
+			if (member.UserID == userDetails.Data.ID) && (member.Role == utils.MemberOwnerRole || member.Role == utils.MemberEditorRole) {
+				editAccess = true
+			}
+		}
+		if !editAccess {
+			utils.Red.Println("⛔ User doesn't have edit access to the project!!")
+			os.Exit(1)
+		}
+
+		chaosExperiment, err := getExperiment(cmd.Context(), pid, eid, credentials)
+		if err != nil {
+			utils.Red.Println("❌ " + err.Error())
+			os.Exit(utils.ExitCode(err))
+		}
+
+		if cron != "" || timezone != "" {
+			manifest, err := scheduleManifest(chaosExperiment.ExperimentManifest, cron, timezone)
+			if err != nil {
+				utils.Red.Println("❌ " + err.Error())
+				os.Exit(1)
+			}
+
+			request, err := scheduleRequest(chaosExperiment, manifest)
+			if err != nil {
+				utils.Red.Println("❌ " + err.Error())
+				os.Exit(1)
+			}
+
+			_, err = experiment.SaveExperiment(cmd.Context(), pid, request, credentials)
+			if err != nil {
+				utils.Red.Println("❌ Failed to update the schedule of the Chaos Experiment: " + err.Error())
+				os.Exit(utils.ExitCode(err))
+			}
+
+			schedule, err := utils.CronWorkflowSchedule(manifest)
+			utils.PrintError(err)
+			chaosExperiment.CronSyntax = schedule.Schedule
+			chaosExperiment.ExperimentManifest = string(manifest)
+			utils.White_B.Println("⏰ Chaos Experiment " + chaosExperiment.Name + " is scheduled with " + schedule.Schedule)
+		}
+
+		if suspend || resume {
+			if chaosExperiment.CronSyntax == "" {
+				utils.Red.Println("⛔ Chaos Experiment " + chaosExperiment.Name + " isn't a cron Chaos Experiment, schedule it with --cron first")
+				os.Exit(1)
+			}
+
+			_, err = experiment.UpdateCronExperimentState(cmd.Context(), pid, eid, suspend, credentials)
+			if err != nil {
+				utils.Red.Println("❌ Failed to update the state of the cron Chaos Experiment: " + err.Error())
+				os.Exit(utils.ExitCode(err))
+			}
+			if suspend {
+				utils.White_B.Println("\n🚀 Chaos Experiment " + chaosExperiment.Name + " schedule suspended successfully 🎉")
+				return
+			}
+		}
+
+		schedule, err := utils.CronWorkflowSchedule([]byte(chaosExperiment.ExperimentManifest))
+		if err == nil && schedule.Suspended && !resume {
+			utils.White_B.Println("\n⏸️ The schedule of Chaos Experiment " + chaosExperiment.Name + " is suspended, resume it with --resume")
+			return
+		}
+		if timezone == "" {
+			timezone = schedule.Timezone
+		}
+		next, err := utils.NextCronTime(chaosExperiment.CronSyntax, timezone, time.Now())
+		utils.PrintError(err)
+		nextRun := next.Format("January 2 2006, 03:04:05 pm MST")
+		if timezone == "" {
+			// The experiment runs in the timezone of its infrastructure,
+			// which isn't known here
+			nextRun += " (local time, the schedule follows the timezone of the Chaos Infrastructure)"
+		}
+		utils.White_B.Println("\n🚀 Chaos Experiment " + chaosExperiment.Name + " will run next on " + nextRun + " 🎉")
+	},
+}
+
+// scheduleRequest returns the request saving the experiment with the
+// scheduled manifest, keeping its name, description, tags and infrastructure
+func scheduleRequest(chaosExperiment *model.Experiment, manifest []byte) (model.SaveChaosExperimentRequest, error) {
+	if chaosExperiment.Infra == nil {
+		return model.SaveChaosExperimentRequest{}, errors.New("Chaos Experiment " + chaosExperiment.Name + " has no Chaos Infrastructure, it may have been deleted. " +
+			"Assign the experiment to a Chaos Infrastructure with litmusctl save chaos-experiment --chaos-infra-id first")
+	}
+
+	request := model.SaveChaosExperimentRequest{
+		ID:          chaosExperiment.ExperimentID,
+		Description: chaosExperiment.Description,
+		Tags:        chaosExperiment.Tags,
+		InfraID:     chaosExperiment.Infra.InfraID,
+	}
+	if err := utils.ParseExperimentManifestData(manifest, &request); err != nil {
+		return request, errors.New("Error parsing Chaos Experiment manifest: " + err.Error())
+	}
+	// The existing name is kept, a generateName would create a new one
+	request.Name = chaosExperiment.Name
+
+	return request, nil
+}
+
+// getExperiment returns the experiment with the given ID in the project
+func getExperiment(ctx context.Context, pid string, eid string, credentials types.Credentials) (*model.Experiment, error) {
+	experiments, err := experiment.GetExperimentList(ctx, pid, model.ListExperimentRequest{ExperimentIDs: []*string{&eid}}, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if len(experiments.Data.ListExperimentDetails.Experiments) == 0 {
+		return nil, errors.New("no Chaos Experiment found with ID " + eid)
+	}
+	return experiments.Data.ListExperimentDetails.Experiments[0], nil
+}
+
+// scheduleManifest sets the schedule and timezone of an experiment manifest,
+// turning a Workflow into a CronWorkflow. Empty values are left unchanged.
+func scheduleManifest(manifest string, cron string, timezone string) ([]byte, error) {
+	var typeMeta metav1.TypeMeta
+	if err := utils.UnmarshalObject([]byte(manifest), &typeMeta); err != nil {
+		return nil, err
+	}
+
+	var cronWorkflow v1alpha1.CronWorkflow
+	switch typeMeta.Kind {
+	case workflow.CronWorkflowKind:
+		if err := utils.UnmarshalObject([]byte(manifest), &cronWorkflow); err != nil {
+			return nil, err
+		}
+	case workflow.WorkflowKind:
+		if cron == "" {
+			return nil, errors.New("the Chaos Experiment isn't a cron Chaos Experiment, pass its schedule with --cron")
+		}
+		var wf v1alpha1.Workflow
+		if err := utils.UnmarshalObject([]byte(manifest), &wf); err != nil {
+			return nil, err
+		}
+		cronWorkflow = v1alpha1.CronWorkflow{
+			TypeMeta: metav1.TypeMeta{APIVersion: workflow.APIVersion, Kind: workflow.CronWorkflowKind},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        wf.Name,
+				Namespace:   wf.Namespace,
+				Labels:      wf.Labels,
+				Annotations: wf.Annotations,
+			},
+			Spec: v1alpha1.CronWorkflowSpec{WorkflowSpec: wf.Spec},
+		}
+	default:
+		return nil, errors.New("invalid resource kind " + typeMeta.Kind + " in the Chaos Experiment manifest")
+	}
+
+	if cron != "" {
+		cronWorkflow.Spec.Schedule = cron
+		cronWorkflow.Spec.Schedules = nil
+	}
+	if timezone != "" {
+		cronWorkflow.Spec.Timezone = timezone
+	}
+
+	// The manifest is parsed again as YAML, where its kind is looked up
+	return yaml.Marshal(cronWorkflow)
+}
+
+func init() {
+	ScheduleCmd.AddCommand(experimentCmd)
+
+	experimentCmd.Flags().String("project-id", "", "Set the project-id of the Chaos Experiment. To see the projects, apply litmusctl get projects")
+	experimentCmd.Flags().String("experiment-id", "", "Set the ID of the Chaos Experiment to schedule. To see the experiments, apply litmusctl get chaos-experiments")
+	experimentCmd.Flags().String("cron", "", "Set the cron schedule of the Chaos Experiment, e.g. \"0 2 * * *\", @daily or \"@every 6h\"")
+	experimentCmd.Flags().String("timezone", "", "Set the timezone of the schedule, e.g. Europe/Paris. Without one, the schedule follows the timezone of the Chaos Infrastructure and next run times are shown in local time")
+	experimentCmd.Flags().Bool("suspend", false, "Suspend the schedule of the cron Chaos Experiment")
+	experimentCmd.Flags().Bool("resume", false, "Resume the schedule of the cron Chaos Experiment")
+	experimentCmd.MarkFlagsMutuallyExclusive("suspend", "resume")
+}
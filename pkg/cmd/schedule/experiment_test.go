@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+)
+
+const testWorkflow = `{"apiVersion":"argoproj.io/v1alpha1","kind":"Workflow",
+"metadata":{"name":"pod-delete-cart","namespace":"litmus","labels":{"subject":"pod-delete-cart"}},
+"spec":{"entrypoint":"pod-delete","templates":[{"name":"pod-delete","metadata":{"labels":{"weight":"10"}},
+"inputs":{"artifacts":[{"name":"pod-delete","path":"/tmp/chaosengine.yaml","raw":{"data":"apiVersion: litmuschaos.io/v1alpha1\nkind: ChaosEngine\nmetadata:\n  generateName: pod-delete\nspec:\n  experiments:\n    - name: pod-delete\n"}}]},
+"container":{"image":"litmuschaos/k8s:latest"}}]}}`
+
+func TestScheduleManifest(t *testing.T) {
+	manifest, err := scheduleManifest(testWorkflow, "0 2 * * *", "Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schedule, err := utils.CronWorkflowSchedule(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Schedule != "0 2 * * *" || schedule.Timezone != "Europe/Paris" {
+		t.Errorf("scheduleManifest() schedule = %+v, want 0 2 * * * in Europe/Paris", schedule)
+	}
+
+	var request model.SaveChaosExperimentRequest
+	if err := utils.ParseExperimentManifestData(manifest, &request); err != nil {
+		t.Fatal(err)
+	}
+	if request.Type == nil || *request.Type != model.ExperimentTypeCronExperiment {
+		t.Errorf("ParseExperimentManifestData() type = %v, want %s", request.Type, model.ExperimentTypeCronExperiment)
+	}
+	if request.Name != "pod-delete-cart" || !strings.Contains(request.Manifest, `"schedule":"0 2 * * *"`) {
+		t.Errorf("ParseExperimentManifestData() = %s %s, want the cron workflow pod-delete-cart", request.Name, request.Manifest)
+	}
+
+	// The timezone alone keeps the schedule of a cron experiment
+	manifest, err = scheduleManifest(request.Manifest, "", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule, _ := utils.CronWorkflowSchedule(manifest); schedule.Schedule != "0 2 * * *" || schedule.Timezone != "UTC" {
+		t.Errorf("scheduleManifest() schedule = %+v, want 0 2 * * * in UTC", schedule)
+	}
+}
+
+func TestScheduleManifestErrors(t *testing.T) {
+	if _, err := scheduleManifest(testWorkflow, "", "UTC"); err == nil {
+		t.Error("scheduleManifest() of a Workflow without --cron error = nil, want an error")
+	}
+
+	deployment, _ := json.Marshal(map[string]string{"apiVersion": "apps/v1", "kind": "Deployment"})
+	if _, err := scheduleManifest(string(deployment), "@daily", ""); err == nil {
+		t.Error("scheduleManifest() of a Deployment error = nil, want an error")
+	}
+}
+
+func TestScheduleRequest(t *testing.T) {
+	manifest, err := scheduleManifest(testWorkflow, "0 2 * * *", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chaosExperiment := &model.Experiment{ExperimentID: "e1", Name: "pod-delete-cart-ab12c", Tags: []string{"nightly"}}
+	if _, err := scheduleRequest(chaosExperiment, manifest); err == nil || !strings.Contains(err.Error(), "--chaos-infra-id") {
+		t.Errorf("scheduleRequest() without a Chaos Infrastructure error = %v, want an error asking to assign one", err)
+	}
+
+	chaosExperiment.Infra = &model.Infra{InfraID: "infra-1"}
+	request, err := scheduleRequest(chaosExperiment, manifest)
+	if err != nil {
+		t.Fatalf("scheduleRequest() error = %v", err)
+	}
+	if request.ID != "e1" || request.Name != "pod-delete-cart-ab12c" || request.InfraID != "infra-1" || len(request.Tags) != 1 {
+		t.Errorf("scheduleRequest() = %+v, want the ID, name, tags and infrastructure of the experiment", request)
+	}
+
+	// A new schedule keeps a suspended cron experiment suspended
+	var cronWorkflow map[string]interface{}
+	if err := json.Unmarshal([]byte(request.Manifest), &cronWorkflow); err != nil {
+		t.Fatal(err)
+	}
+	cronWorkflow["spec"].(map[string]interface{})["suspend"] = true
+	suspended, _ := json.Marshal(cronWorkflow)
+	manifest, err = scheduleManifest(string(suspended), "@daily", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule, _ := utils.CronWorkflowSchedule(manifest); !schedule.Suspended || schedule.Schedule != "@daily" {
+		t.Errorf("scheduleManifest() schedule = %+v, want @daily and suspended", schedule)
+	}
+}
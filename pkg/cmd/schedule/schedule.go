@@ -0,0 +1,35 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package schedule
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ScheduleCmd represents the schedule command
+var ScheduleCmd = &cobra.Command{
+	Use: "schedule",
+	Short: `Manage the schedule of resources of ChaosCenter
+		Examples:
+		#run a Chaos Experiment every day at 2am in the given timezone
+		litmusctl schedule chaos-experiment --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --experiment-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c" --cron="0 2 * * *" --timezone="Europe/Paris"
+
+		#suspend the schedule of a cron Chaos Experiment
+		litmusctl schedule chaos-experiment --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --experiment-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c" --suspend
+
+		Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
+	`,
+}
@@ -0,0 +1,308 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package stop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis"
+	"github.com/litmuschaos/litmusctl/pkg/apis/experiment"
+	"github.com/litmuschaos/litmusctl/pkg/k8s"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+)
+
+// revertedStatuses are the engineStatus of ChaosEngines no longer injecting chaos
+var revertedStatuses = map[string]bool{"stopped": true, "completed": true}
+
+// pollInterval is the delay between two checks of the ChaosEngines with --wait
+var pollInterval = 2 * time.Second
+
+// chaosEngine is a ChaosEngine created by a run
+type chaosEngine struct {
+	namespace string
+	name      string
+}
+
+func (e chaosEngine) String() string {
+	return e.namespace + "/" + e.name
+}
+
+// experimentRunCmd represents the chaos-experiment-run command
+var experimentRunCmd = &cobra.Command{
+	Use:   "chaos-experiment-run",
+	Short: "Stop a Chaos Experiment run, or all the runs of a Chaos Experiment",
+	Long: `Stop a Chaos Experiment run given with --run-id, or all the unfinished runs of the Chaos Experiment given with --experiment-id,
+including the ones that did not start yet.
+With --wait, the ChaosEngines created by the runs are then checked in the cluster of the kubeconfig until they are
+stopped or deleted, so the kubeconfig has to point to the Chaos Infrastructure of the runs.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		credentials, err := utils.GetCredentials(cmd)
+		utils.PrintError(err)
+
+		pid, err := utils.GetProjectID(cmd)
+		utils.PrintError(err)
+		if pid == "" {
+			utils.Red.Println("⛔ Project ID can't be empty, pass it with --project-id")
+			os.Exit(1)
+		}
+
+		runID, err := cmd.Flags().GetString("run-id")
+		utils.PrintError(err)
+		eid, err := cmd.Flags().GetString("experiment-id")
+		utils.PrintError(err)
+		if runID == "" && eid == "" {
+			utils.Red.Println("⛔ Pass the Chaos Experiment run to stop with --run-id, or the Chaos Experiment with --experiment-id")
+			os.Exit(1)
+		}
+
+		wait, err := cmd.Flags().GetBool("wait")
+		utils.PrintError(err)
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		utils.PrintError(err)
+
+		// Perform authorization
+		userDetails, err := apis.GetProjectDetails(cmd.Context(), credentials)
+		utils.PrintError(err)
+		var editAccess = false
+		var project apis.Project
+		for _, p := range userDetails.Data.Projects {
+			if p.ID == pid {
+				project = p
+			}
+		}
+		for _, member := range project.Members {
+			if (member.UserID == userDetails.Data.ID) && (member.Role == utils.MemberOwnerRole || member.Role == utils.MemberEditorRole) {
+				editAccess = true
+			}
+		}
+		if !editAccess {
+			utils.Red.Println("⛔ User doesn't have edit access to the project!!")
+			os.Exit(1)
+		}
+
+		// The dynamic client is created first, so that a wrong kubeconfig
+		// fails before anything is stopped
+		var dynamicClient dynamic.Interface
+		if wait {
+			kubeconfig, err := utils.GetKubeconfig(cmd)
+			utils.PrintError(err)
+			dynamicClient, err = k8s.DynamicClient(kubeconfig)
+			utils.PrintError(err)
+		}
+
+		runs, err := stopRuns(cmd.Context(), pid, eid, runID, credentials)
+		if errors.Is(err, apis.ErrNotFound) {
+			utils.Red.Println("❌ The Chaos Experiment run or Chaos Experiment doesn't exist in the project")
+			os.Exit(utils.ExitCode(err))
+		}
+		if err != nil {
+			utils.Red.Println("❌ Failed to stop the Chaos Experiment runs: " + err.Error())
+			os.Exit(utils.ExitCode(err))
+		}
+		if len(runs) == 0 {
+			utils.White_B.Println("🛑 Stop requested, no unfinished Chaos Experiment runs were found")
+			return
+		}
+		for _, run := range runs {
+			utils.White_B.Println("🛑 Stopped Chaos Experiment run " + run.ExperimentRunID + " of " + run.ExperimentName)
+		}
+
+		if !wait {
+			return
+		}
+
+		engines, err := runChaosEngines(runs)
+		utils.PrintError(err)
+		if len(engines) == 0 {
+			utils.White_B.Println("No ChaosEngines were created by the runs")
+			return
+		}
+
+		err = waitForEngines(cmd.Context(), dynamicClient, engines, timeout, func(engine chaosEngine, status string) {
+			if status == "" {
+				status = "deleted"
+			}
+			utils.White.Println("✅ ChaosEngine " + engine.String() + " " + status)
+		})
+		if err != nil {
+			utils.Red.Println("❌ " + err.Error())
+			os.Exit(utils.ExitCode(err))
+		}
+		utils.White_B.Println("\n🚀 All the ChaosEngines of the runs are reverted 🎉")
+	},
+}
+
+// stopRuns stops the given run, or all the runs of the experiment when runID
+// is empty, and returns the runs that were not finished yet. The stop is sent
+// even when no such run is found, as a run may start in the meantime.
+func stopRuns(ctx context.Context, pid string, eid string, runID string, credentials types.Credentials) ([]*model.ExperimentRun, error) {
+	// The runs are fetched before they are stopped, as stopping them
+	// removes their workflow
+	runs, experimentID, err := unfinishedRuns(ctx, pid, eid, runID, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	var runIDPtr *string
+	if runID != "" {
+		runIDPtr = &runID
+	}
+	if _, err := experiment.StopExperimentRuns(ctx, pid, experimentID, runIDPtr, credentials); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// unfinishedRuns returns the run to stop, or the runs of the experiment when
+// runID is empty, along with the ID of their experiment. Runs that already
+// finished are left out, runs that are queued or didn't start yet are kept.
+func unfinishedRuns(ctx context.Context, pid string, eid string, runID string, credentials types.Credentials) ([]*model.ExperimentRun, string, error) {
+	var runIDs []string
+	if runID != "" {
+		runIDs = []string{runID}
+	} else {
+		list, err := experiment.GetExperimentRunsList(ctx, pid, model.ListExperimentRunRequest{
+			ExperimentIDs: []*string{&eid},
+		}, credentials)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, run := range list.Data.ListExperimentRunDetails.ExperimentRuns {
+			if !experiment.IsTerminalPhase(run.Phase) {
+				runIDs = append(runIDs, run.ExperimentRunID)
+			}
+		}
+	}
+
+	var runs []*model.ExperimentRun
+	for _, id := range runIDs {
+		run, err := experiment.GetExperimentRun(ctx, pid, id, credentials)
+		if err != nil {
+			return nil, "", err
+		}
+		if eid != "" && run.Data.ExperimentRun.ExperimentID != eid {
+			return nil, "", errors.New("the Chaos Experiment run " + id + " doesn't belong to the Chaos Experiment " + eid)
+		}
+		eid = run.Data.ExperimentRun.ExperimentID
+		if experiment.IsTerminalPhase(run.Data.ExperimentRun.Phase) {
+			utils.White.Println("Chaos Experiment run " + id + " already finished with phase " + string(run.Data.ExperimentRun.Phase))
+			continue
+		}
+		runs = append(runs, &run.Data.ExperimentRun)
+	}
+	return runs, eid, nil
+}
+
+// runChaosEngines returns the ChaosEngines created by the runs, as found in
+// their execution data
+func runChaosEngines(runs []*model.ExperimentRun) ([]chaosEngine, error) {
+	seen := map[chaosEngine]bool{}
+	var engines []chaosEngine
+	for _, run := range runs {
+		if run.ExecutionData == "" {
+			continue
+		}
+		var execution types.ExecutionData
+		if err := json.Unmarshal([]byte(run.ExecutionData), &execution); err != nil {
+			return nil, errors.New("invalid execution data of the Chaos Experiment run " + run.ExperimentRunID + ": " + err.Error())
+		}
+
+		for _, node := range execution.Nodes {
+			if node.ChaosData == nil || node.ChaosData.EngineName == "" {
+				continue
+			}
+			engine := chaosEngine{namespace: node.ChaosData.Namespace, name: node.ChaosData.EngineName}
+			if engine.namespace == "" {
+				engine.namespace = execution.Namespace
+			}
+			if !seen[engine] {
+				seen[engine] = true
+				engines = append(engines, engine)
+			}
+		}
+	}
+
+	sort.Slice(engines, func(i, j int) bool {
+		return engines[i].String() < engines[j].String()
+	})
+	return engines, nil
+}
+
+// waitForEngines polls the ChaosEngines until each one is stopped, completed
+// or deleted. reverted is called once for each of them.
+func waitForEngines(ctx context.Context, client dynamic.Interface, engines []chaosEngine, timeout time.Duration, reverted func(chaosEngine, string)) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	pending := engines
+	for {
+		var remaining []chaosEngine
+		for _, engine := range pending {
+			status, exists, err := k8s.ChaosEngineStatus(ctx, client, engine.namespace, engine.name)
+			if err != nil {
+				return errors.New("failed to get ChaosEngine " + engine.String() + ": " + err.Error())
+			}
+			if !exists || revertedStatuses[status] {
+				reverted(engine, status)
+				continue
+			}
+			remaining = append(remaining, engine)
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		pending = remaining
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				names := ""
+				for i, engine := range pending {
+					if i > 0 {
+						names += ", "
+					}
+					names += engine.String()
+				}
+				return errors.New("timed out after " + timeout.String() + " waiting for the ChaosEngines " + names + " to be reverted")
+			}
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func init() {
+	StopCmd.AddCommand(experimentRunCmd)
+
+	experimentRunCmd.Flags().String("project-id", "", "Set the project-id of the Chaos Experiment run. To see the projects, apply litmusctl get projects")
+	experimentRunCmd.Flags().String("run-id", "", "Set the ID of the Chaos Experiment run to stop. To see the runs, apply litmusctl get chaos-experiment-runs")
+	experimentRunCmd.Flags().String("experiment-id", "", "Set the ID of the Chaos Experiment to stop all of its runs")
+	experimentRunCmd.Flags().Bool("wait", false, "Wait until the ChaosEngines of the runs are reverted in the cluster")
+	experimentRunCmd.Flags().Duration("timeout", 5*time.Minute, "How long to wait for the ChaosEngines with --wait, 0 waits forever")
+	experimentRunCmd.Flags().StringP("kubeconfig", "k", "", "Set to pass kubeconfig file if it is not in the default location ($HOME/.kube/config)")
+}
@@ -0,0 +1,200 @@
+package stop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/litmuschaos/litmusctl/pkg/apis/apitest"
+	"github.com/litmuschaos/litmusctl/pkg/k8s"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func testEngine(namespace string, name string, status string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "litmuschaos.io/v1alpha1",
+		"kind":       "ChaosEngine",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"status":     map[string]interface{}{"engineStatus": status},
+	}}
+}
+
+func testDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		k8s.ChaosEngineResource: "ChaosEngineList",
+	}, objects...)
+}
+
+func TestRunChaosEngines(t *testing.T) {
+	execution, _ := json.Marshal(types.ExecutionData{
+		Namespace: "litmus",
+		Nodes: map[string]types.ExecutionNode{
+			"1": {Name: "install-chaos-faults"},
+			"2": {Name: "pod-delete", ChaosData: &types.ChaosData{EngineName: "pod-delete-abc12", Namespace: "apps"}},
+			"3": {Name: "cpu-hog", ChaosData: &types.ChaosData{EngineName: "pod-cpu-hog-def34"}},
+		},
+	})
+	runs := []*model.ExperimentRun{
+		{ExperimentRunID: "run-1", ExecutionData: string(execution)},
+		{ExperimentRunID: "run-2", ExecutionData: string(execution)},
+		{ExperimentRunID: "run-3"},
+	}
+
+	engines, err := runChaosEngines(runs)
+	if err != nil {
+		t.Fatalf("runChaosEngines() error = %v", err)
+	}
+	want := []chaosEngine{{namespace: "apps", name: "pod-delete-abc12"}, {namespace: "litmus", name: "pod-cpu-hog-def34"}}
+	if !reflect.DeepEqual(engines, want) {
+		t.Errorf("runChaosEngines() = %v, want %v", engines, want)
+	}
+}
+
+func TestWaitForEngines(t *testing.T) {
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = 2 * time.Second }()
+
+	engines := []chaosEngine{{namespace: "apps", name: "stopped"}, {namespace: "apps", name: "deleted"}, {namespace: "apps", name: "running"}}
+	client := testDynamicClient(testEngine("apps", "stopped", "stopped"), testEngine("apps", "running", "initialized"))
+
+	// The running engine is stopped by the operator after a few polls
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		client.Resource(k8s.ChaosEngineResource).Namespace("apps").Update(context.Background(), testEngine("apps", "running", "stopped"), metav1.UpdateOptions{})
+	}()
+
+	var reverted []string
+	err := waitForEngines(context.Background(), client, engines, time.Minute, func(engine chaosEngine, status string) {
+		reverted = append(reverted, engine.name+"="+status)
+	})
+	if err != nil {
+		t.Fatalf("waitForEngines() error = %v", err)
+	}
+	want := []string{"stopped=stopped", "deleted=", "running=stopped"}
+	if !reflect.DeepEqual(reverted, want) {
+		t.Errorf("Reverted %v, want %v", reverted, want)
+	}
+}
+
+func TestWaitForEnginesTimeout(t *testing.T) {
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = 2 * time.Second }()
+
+	client := testDynamicClient(testEngine("apps", "running", "initialized"))
+	err := waitForEngines(context.Background(), client, []chaosEngine{{namespace: "apps", name: "running"}}, 20*time.Millisecond, func(chaosEngine, string) {})
+	if err == nil || !strings.Contains(err.Error(), "apps/running") {
+		t.Errorf("waitForEngines() error = %v, want a timeout naming apps/running", err)
+	}
+}
+
+// fakeRunServer answers the requests of stopRuns with the given runs, and
+// records the stop requests
+func fakeRunServer(t *testing.T, runs []*model.ExperimentRun, stopped *[]map[string]string) *httptest.Server {
+	return apitest.NewServer(t, map[string]apitest.Handler{
+		"listExperimentRun": apitest.Respond(model.ListExperimentRunResponse{ExperimentRuns: runs}),
+		"getExperimentRun": func(request apitest.Request) (interface{}, error) {
+			var runID string
+			if err := request.Decode("experimentRunID", &runID); err != nil {
+				return nil, err
+			}
+			for _, run := range runs {
+				if run.ExperimentRunID == runID {
+					return run, nil
+				}
+			}
+			return nil, errors.New("unknown run " + runID)
+		},
+		"stopExperimentRuns": func(request apitest.Request) (interface{}, error) {
+			stop := map[string]string{}
+			var experimentID, runID string
+			if err := request.Decode("experimentID", &experimentID); err != nil {
+				return nil, err
+			}
+			if err := request.Decode("experimentRunID", &runID); err != nil {
+				return nil, err
+			}
+			stop["experimentID"], stop["experimentRunID"] = experimentID, runID
+			*stopped = append(*stopped, stop)
+			return true, nil
+		},
+	})
+}
+
+func TestStopRuns(t *testing.T) {
+	runs := []*model.ExperimentRun{
+		{ExperimentRunID: "queued", ExperimentID: "e1", Phase: model.ExperimentRunStatusNa},
+		{ExperimentRunID: "running", ExperimentID: "e1", Phase: model.ExperimentRunStatusRunning},
+		{ExperimentRunID: "done", ExperimentID: "e1", Phase: model.ExperimentRunStatusCompleted},
+	}
+
+	tests := []struct {
+		name      string
+		eid       string
+		runID     string
+		runs      []*model.ExperimentRun
+		wantRuns  []string
+		wantStops []map[string]string
+	}{
+		{
+			name:      "experiment with a run not started yet",
+			eid:       "e1",
+			runs:      runs,
+			wantRuns:  []string{"queued", "running"},
+			wantStops: []map[string]string{{"experimentID": "e1", "experimentRunID": ""}},
+		},
+		{
+			name:      "run not started yet",
+			runID:     "queued",
+			runs:      runs,
+			wantRuns:  []string{"queued"},
+			wantStops: []map[string]string{{"experimentID": "e1", "experimentRunID": "queued"}},
+		},
+		{
+			name:      "finished run is stopped anyway",
+			runID:     "done",
+			runs:      runs,
+			wantStops: []map[string]string{{"experimentID": "e1", "experimentRunID": "done"}},
+		},
+		{
+			name:      "experiment without runs is stopped anyway",
+			eid:       "e2",
+			wantStops: []map[string]string{{"experimentID": "e2", "experimentRunID": ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stopped []map[string]string
+			server := fakeRunServer(t, tt.runs, &stopped)
+			defer server.Close()
+
+			credentials := types.Credentials{Endpoint: server.URL, ServerEndpoint: server.URL, Token: "token"}
+			got, err := stopRuns(context.Background(), "project-1", tt.eid, tt.runID, credentials)
+			if err != nil {
+				t.Fatalf("stopRuns() error = %v", err)
+			}
+
+			var gotRuns []string
+			for _, run := range got {
+				gotRuns = append(gotRuns, run.ExperimentRunID)
+			}
+			if !reflect.DeepEqual(gotRuns, tt.wantRuns) {
+				t.Errorf("stopRuns() runs = %v, want %v", gotRuns, tt.wantRuns)
+			}
+			if !reflect.DeepEqual(stopped, tt.wantStops) {
+				t.Errorf("stopRuns() stop requests = %v, want %v", stopped, tt.wantStops)
+			}
+		})
+	}
+}
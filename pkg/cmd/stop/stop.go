@@ -0,0 +1,35 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package stop
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// StopCmd represents the stop command
+var StopCmd = &cobra.Command{
+	Use: "stop",
+	Short: `Stop running resources of ChaosCenter
+		Examples:
+		#stop a Chaos Experiment run, and wait for its ChaosEngines to be reverted
+		litmusctl stop chaos-experiment-run --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --run-id="3c1c3ab9-ef52-4c1e-87a3-8f0d26e7c2a5" --wait
+
+		#stop all the runs of a Chaos Experiment
+		litmusctl stop chaos-experiment-run --project-id="d861b650-1549-4574-b2ba-ab754058dd04" --experiment-id="1c9c5801-8789-4ac9-bf5f-32649b707a5c"
+
+		Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
+	`,
+}
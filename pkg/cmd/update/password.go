@@ -75,6 +75,7 @@ var PasswordCmd = &cobra.Command{
 		payloadBytes, _ := json.Marshal(updatePasswordRequest)
 
 		resp, err := apis.SendRequest(
+			cmd.Context(),
 			apis.SendRequestParams{
 				Endpoint: credentials.Endpoint + utils.AuthAPIPath + "/update/password",
 				Token:    "Bearer " + credentials.Token,
@@ -16,10 +16,9 @@ limitations under the License.
 package upgrade
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/litmuschaos/litmusctl/pkg/apis"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
@@ -34,7 +33,7 @@ var infraCmd = &cobra.Command{
 		credentials, err := utils.GetCredentials(cmd)
 		utils.PrintError(err)
 
-		projectID, err := cmd.Flags().GetString("project-id")
+		projectID, err := utils.GetProjectID(cmd)
 		utils.PrintError(err)
 
 		if projectID == "" {
@@ -42,7 +41,7 @@ var infraCmd = &cobra.Command{
 			fmt.Scanln(&projectID)
 		}
 
-		infraID, err := cmd.Flags().GetString("chaos-infra-id")
+		infraID, err := utils.GetInfraID(cmd)
 		utils.PrintError(err)
 
 		if infraID == "" {
@@ -50,17 +49,17 @@ var infraCmd = &cobra.Command{
 			fmt.Scanln(&infraID)
 		}
 
-		kubeconfig, err := cmd.Flags().GetString("kubeconfig")
+		kubeconfig, err := utils.GetKubeconfig(cmd)
 		utils.PrintError(err)
 
-		output, err := apis.UpgradeInfra(context.Background(), credentials, projectID, infraID, kubeconfig)
+		output, err := apis.UpgradeInfra(cmd.Context(), credentials, projectID, infraID, kubeconfig)
 		if err != nil {
-			if strings.Contains(err.Error(), "no documents in result") {
+			if errors.Is(err, apis.ErrNotFound) {
 				utils.Red.Println("❌ The specified Project ID or Chaos Infrastructure ID doesn't exist.")
-				os.Exit(1)
+				os.Exit(utils.ExitCode(err))
 			}
 			utils.Red.Print("\n❌ Failed upgrading Chaos Infrastructure: \n" + err.Error() + "\n")
-			os.Exit(1)
+			os.Exit(utils.ExitCode(err))
 		}
 		utils.White_B.Print("\n", output)
 	},
@@ -0,0 +1,92 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package validate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/litmuschaos/litmusctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// experimentCmd represents the chaos-experiment command
+var experimentCmd = &cobra.Command{
+	Use:   "chaos-experiment",
+	Short: "Validate the manifests of Chaos Experiments offline",
+	Long: `Validate Workflow and CronWorkflow manifests of Chaos Experiments without ChaosCenter: the workflow and its schedule,
+and each ChaosEngine of its raw artifacts, with its fault names, experiments, appinfo, weight label and probes.
+Every problem is printed with its template and path. Exits with 1 when a manifest has problems, so that it can run
+as a pre-commit hook.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		files, err := cmd.Flags().GetStringArray("file")
+		utils.PrintError(err)
+		files = append(files, args...)
+		if len(files) == 0 {
+			utils.Red.Println("⛔ Pass the manifests to validate with -f")
+			os.Exit(1)
+		}
+
+		manifests, err := utils.ExpandManifestPaths(files)
+		utils.PrintError(err)
+
+		if problems := validateManifests(manifests, os.Stdout); problems > 0 {
+			utils.Red.Println("\n❌ Found " + strconv.Itoa(problems) + " problems")
+			os.Exit(1)
+		}
+	},
+}
+
+// validateManifests prints the problems of each manifest and returns their
+// number
+func validateManifests(manifests []string, out io.Writer) int {
+	problems := 0
+	for _, manifest := range manifests {
+		body, err := utils.ReadManifest(manifest)
+		if err != nil {
+			fmt.Fprintln(out, manifest+": "+err.Error())
+			problems++
+			continue
+		}
+
+		documents := utils.SplitManifests(body)
+		for i, document := range documents {
+			source := manifest
+			if len(documents) > 1 {
+				source += " (document " + strconv.Itoa(i+1) + ")"
+			}
+
+			found := utils.ValidateExperimentManifest(document)
+			for _, problem := range found {
+				fmt.Fprintln(out, source+": "+problem.String())
+			}
+			if len(found) == 0 {
+				fmt.Fprintln(out, source+": valid")
+			}
+			problems += len(found)
+		}
+	}
+
+	return problems
+}
+
+func init() {
+	ValidateCmd.AddCommand(experimentCmd)
+
+	experimentCmd.Flags().StringArrayP("file", "f", nil, "The manifests of the Chaos Experiments, as files, directories, globs or URLs. Can be repeated")
+}
@@ -0,0 +1,32 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package validate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ValidateCmd represents the validate command
+var ValidateCmd = &cobra.Command{
+	Use: "validate",
+	Short: `Validate manifests offline, without ChaosCenter
+		Examples:
+		#validate the manifests of Chaos Experiments
+		litmusctl validate chaos-experiment -f experiments/
+
+		Note: The default location of the config file is $HOME/.litmusconfig, and can be overridden by a --config flag
+	`,
+}
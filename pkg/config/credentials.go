@@ -0,0 +1,518 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+const (
+	// FileCredentialStore keeps tokens in a 0600 file next to the config file,
+	// encrypted with a passphrase when CredentialsPassphraseEnv is set
+	FileCredentialStore = "file"
+	// KeyringCredentialStore keeps tokens in the keyring of the operating system
+	KeyringCredentialStore = "keyring"
+
+	// CredentialsPassphraseEnv holds the passphrase of the file credential store
+	CredentialsPassphraseEnv = "LITMUSCTL_CREDENTIALS_PASSPHRASE"
+
+	credentialsFileSuffix = ".credentials"
+	keyringService        = "litmusctl"
+	pbkdf2Iterations      = 200000
+)
+
+// errTokenNotFound is returned by credential stores for keys they don't hold
+var errTokenNotFound = errors.New("token not found")
+
+// CredentialStore keeps the tokens of the accounts out of the config file,
+// which only holds a reference to them
+type CredentialStore interface {
+	Get(key string) (string, error)
+	Set(key string, secret string) error
+	Delete(key string) error
+}
+
+// NewCredentialStore returns the named credential store of the config file
+func NewCredentialStore(name string, configFile string) (CredentialStore, error) {
+	switch name {
+	case "", FileCredentialStore:
+		return &fileStore{
+			path:       configFile + credentialsFileSuffix,
+			passphrase: os.Getenv(CredentialsPassphraseEnv),
+		}, nil
+	case KeyringCredentialStore:
+		return newKeyringStore()
+	}
+
+	return nil, errors.New("unknown credential store " + name + ", use " + FileCredentialStore + " or " + KeyringCredentialStore)
+}
+
+// tokenKey identifies the token of a user in a credential store
+func tokenKey(endpoint string, username string) string {
+	return username + "@" + endpoint
+}
+
+// parseTokenRef splits a token reference into the store name and the key
+func parseTokenRef(ref string) (string, string, error) {
+	store, key, ok := strings.Cut(ref, ":")
+	if !ok || key == "" {
+		return "", "", errors.New("malformed token reference " + ref)
+	}
+
+	return store, key, nil
+}
+
+// storedTokens are the tokens known to be in their credential store, by
+// config file and token reference, so that config writes don't store
+// unchanged tokens again
+var (
+	storedTokensMu sync.Mutex
+	storedTokens   = map[string]string{}
+)
+
+func storedTokenKey(filename string, ref string) string {
+	return filename + "\n" + ref
+}
+
+func isStoredToken(filename string, ref string, token string) bool {
+	storedTokensMu.Lock()
+	defer storedTokensMu.Unlock()
+
+	stored, ok := storedTokens[storedTokenKey(filename, ref)]
+	return ok && stored == token
+}
+
+func setStoredToken(filename string, ref string, token string) {
+	storedTokensMu.Lock()
+	defer storedTokensMu.Unlock()
+
+	if token == "" {
+		delete(storedTokens, storedTokenKey(filename, ref))
+		return
+	}
+	storedTokens[storedTokenKey(filename, ref)] = token
+}
+
+// storeTokens moves the tokens of obj into its credential store and returns a
+// copy of obj holding only the references to them. Only the tokens that are
+// new or changed since they were loaded are written to the store.
+func storeTokens(obj types.LitmuCtlConfig, filename string) (types.LitmuCtlConfig, error) {
+	storeName := obj.CredentialStore
+	if storeName == "" {
+		storeName = FileCredentialStore
+	}
+
+	changed := map[string]string{}
+	accounts := make([]types.Account, len(obj.Accounts))
+	for i, account := range obj.Accounts {
+		accounts[i] = account
+		accounts[i].Users = make([]types.User, len(account.Users))
+		for j, user := range account.Users {
+			if user.Token != "" {
+				key := tokenKey(account.Endpoint, user.Username)
+				ref := storeName + ":" + key
+				if user.TokenRef != ref || !isStoredToken(filename, ref, user.Token) {
+					changed[key] = user.Token
+				}
+				user.TokenRef = ref
+				user.Token = ""
+			}
+			accounts[i].Users[j] = user
+		}
+	}
+
+	if len(changed) > 0 {
+		store, err := NewCredentialStore(storeName, filename)
+		if err != nil {
+			return obj, err
+		}
+		if err := setTokens(store, changed); err != nil {
+			return obj, err
+		}
+		for key, token := range changed {
+			setStoredToken(filename, storeName+":"+key, token)
+		}
+	}
+
+	obj.Accounts = accounts
+	return obj, nil
+}
+
+// setTokens stores the tokens by key, with a single write for the file store
+func setTokens(store CredentialStore, tokens map[string]string) error {
+	if files, ok := store.(*fileStore); ok {
+		if err := files.setAll(tokens); err != nil {
+			return errors.New("failed to store the tokens: " + err.Error())
+		}
+		return nil
+	}
+
+	keys := make([]string, 0, len(tokens))
+	for key := range tokens {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := store.Set(key, tokens[key]); err != nil {
+			return errors.New("failed to store the token of " + key + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// loadTokens fills in the tokens referenced by the users of obj
+func loadTokens(obj *types.LitmuCtlConfig, filename string) error {
+	stores := map[string]CredentialStore{}
+	for i, account := range obj.Accounts {
+		for j, user := range account.Users {
+			if user.TokenRef == "" {
+				continue
+			}
+
+			storeName, key, err := parseTokenRef(user.TokenRef)
+			if err != nil {
+				return err
+			}
+			store, ok := stores[storeName]
+			if !ok {
+				store, err = NewCredentialStore(storeName, filename)
+				if err != nil {
+					return err
+				}
+				stores[storeName] = store
+			}
+
+			token, err := store.Get(key)
+			if err != nil {
+				return errors.New("failed to load the token of " + key + ": " + err.Error())
+			}
+			obj.Accounts[i].Users[j].Token = token
+			setStoredToken(filename, user.TokenRef, token)
+		}
+	}
+
+	return nil
+}
+
+// loadToken returns the token a token reference points to
+func loadToken(ref string, filename string) (string, error) {
+	storeName, key, err := parseTokenRef(ref)
+	if err != nil {
+		return "", err
+	}
+	store, err := NewCredentialStore(storeName, filename)
+	if err != nil {
+		return "", err
+	}
+
+	return store.Get(key)
+}
+
+// deleteTokens removes the tokens of the users from their credential stores
+func deleteTokens(users []types.User, filename string) error {
+	for _, user := range users {
+		if user.TokenRef == "" {
+			continue
+		}
+
+		storeName, key, err := parseTokenRef(user.TokenRef)
+		if err != nil {
+			return err
+		}
+		store, err := NewCredentialStore(storeName, filename)
+		if err != nil {
+			return err
+		}
+		// Tokens already removed from the store, such as a keyring entry
+		// cleared by hand, are left as they are
+		setStoredToken(filename, user.TokenRef, "")
+		if err := store.Delete(key); err != nil && !errors.Is(err, errTokenNotFound) {
+			return errors.New("failed to delete the token of " + key + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// HasPlaintextTokens reports whether the config file still holds raw tokens
+// written by an older litmusctl
+func HasPlaintextTokens(obj types.LitmuCtlConfig) bool {
+	for _, account := range obj.Accounts {
+		for _, user := range account.Users {
+			if user.Token != "" && user.TokenRef == "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// fileStore keeps the tokens in a json file readable only by its owner
+type fileStore struct {
+	path       string
+	passphrase string
+}
+
+type credentialsFile struct {
+	Tokens    map[string]string `json:"tokens,omitempty"`
+	Salt      []byte            `json:"salt,omitempty"`
+	Nonce     []byte            `json:"nonce,omitempty"`
+	Encrypted []byte            `json:"encrypted,omitempty"`
+}
+
+func (s *fileStore) Get(key string) (string, error) {
+	tokens, err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := tokens[key]
+	if !ok {
+		return "", fmt.Errorf("%w in %s", errTokenNotFound, s.path)
+	}
+	return token, nil
+}
+
+func (s *fileStore) Set(key string, secret string) error {
+	return s.setAll(map[string]string{key: secret})
+}
+
+// setAll stores several tokens with a single load and save of the file
+func (s *fileStore) setAll(secrets map[string]string) error {
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	for key, secret := range secrets {
+		tokens[key] = secret
+	}
+	return s.save(tokens)
+}
+
+func (s *fileStore) Delete(key string) error {
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := tokens[key]; !ok {
+		return fmt.Errorf("%w in %s", errTokenNotFound, s.path)
+	}
+	delete(tokens, key)
+	return s.save(tokens)
+}
+
+func (s *fileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file credentialsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.New("credential store " + s.path + " is corrupted: " + err.Error())
+	}
+
+	if file.Encrypted == nil {
+		if file.Tokens == nil {
+			file.Tokens = map[string]string{}
+		}
+		return file.Tokens, nil
+	}
+
+	if s.passphrase == "" {
+		return nil, errors.New("credential store " + s.path + " is encrypted, set " + CredentialsPassphraseEnv + " to unlock it")
+	}
+	gcm, err := newGCM(s.passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Encrypted, nil)
+	if err != nil {
+		return nil, errors.New("failed to unlock credential store " + s.path + ", check " + CredentialsPassphraseEnv)
+	}
+
+	tokens := map[string]string{}
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (s *fileStore) save(tokens map[string]string) error {
+	file := credentialsFile{Tokens: tokens}
+
+	if s.passphrase != "" {
+		plain, err := json.Marshal(tokens)
+		if err != nil {
+			return err
+		}
+
+		file = credentialsFile{Salt: make([]byte, 16)}
+		if _, err := rand.Read(file.Salt); err != nil {
+			return err
+		}
+		gcm, err := newGCM(s.passphrase, file.Salt)
+		if err != nil {
+			return err
+		}
+		file.Nonce = make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(file.Nonce); err != nil {
+			return err
+		}
+		file.Encrypted = gcm.Seal(nil, file.Nonce, plain, nil)
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	return writePrivateFile(s.path, data)
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// keyringStore keeps the tokens in the keyring of the operating system through
+// its command line tool
+type keyringStore struct {
+	get    func(key string) *exec.Cmd
+	set    func(key string, secret string) *exec.Cmd
+	delete func(key string) *exec.Cmd
+	// notFound tells from the exit code and output of a command whether it
+	// failed because the key isn't in the keyring
+	notFound func(code int, stderr string) bool
+}
+
+func newKeyringStore() (CredentialStore, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("security"); err == nil {
+			return &keyringStore{
+				get: func(key string) *exec.Cmd {
+					return exec.Command("security", "find-generic-password", "-s", keyringService, "-a", key, "-w")
+				},
+				set: securityAddPassword,
+				delete: func(key string) *exec.Cmd {
+					return exec.Command("security", "delete-generic-password", "-s", keyringService, "-a", key)
+				},
+				// errSecItemNotFound
+				notFound: func(code int, stderr string) bool { return code == 44 },
+			}, nil
+		}
+	case "linux", "freebsd", "openbsd":
+		if _, err := exec.LookPath("secret-tool"); err == nil {
+			return &keyringStore{
+				get: func(key string) *exec.Cmd {
+					return exec.Command("secret-tool", "lookup", "service", keyringService, "account", key)
+				},
+				set: func(key string, secret string) *exec.Cmd {
+					cmd := exec.Command("secret-tool", "store", "--label", keyringService+" "+key, "service", keyringService, "account", key)
+					cmd.Stdin = strings.NewReader(secret)
+					return cmd
+				},
+				delete: func(key string) *exec.Cmd {
+					return exec.Command("secret-tool", "clear", "service", keyringService, "account", key)
+				},
+				// secret-tool exits with 1 and no message when nothing matches
+				notFound: func(code int, stderr string) bool { return code == 1 && stderr == "" },
+			}, nil
+		}
+	}
+
+	return nil, errors.New("no keyring is available on this system, use the " + FileCredentialStore + " credential store")
+}
+
+// securityAddPassword stores the secret in the macOS keychain. The command is
+// written on the stdin of security -i rather than passed as arguments, which
+// any local user could read with ps, and the secret is hex encoded with -X.
+func securityAddPassword(key string, secret string) *exec.Cmd {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader("add-generic-password -U -s " + securityQuote(keyringService) + " -a " + securityQuote(key) +
+		" -X " + hex.EncodeToString([]byte(secret)) + "\n")
+	return cmd
+}
+
+// securityQuote quotes an argument of a security -i command
+func securityQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+}
+
+func (s *keyringStore) Get(key string) (string, error) {
+	out, err := s.run(s.get(key))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (s *keyringStore) Set(key string, secret string) error {
+	_, err := s.run(s.set(key, secret))
+	return err
+}
+
+func (s *keyringStore) Delete(key string) error {
+	_, err := s.run(s.delete(key))
+	return err
+}
+
+func (s *keyringStore) run(cmd *exec.Cmd) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && s.notFound != nil && s.notFound(exitErr.ExitCode(), strings.TrimSpace(stderr.String())) {
+			return "", fmt.Errorf("%w in the keyring", errTokenNotFound)
+		}
+		return "", errors.New("keyring: " + strings.TrimSpace(stderr.String()) + " " + err.Error())
+	}
+
+	return stdout.String(), nil
+}
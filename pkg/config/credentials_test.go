@@ -0,0 +1,180 @@
+package config
+
+import (
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+func newTokenConfig(token string) types.LitmuCtlConfig {
+	return types.LitmuCtlConfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentAccount: "https://test.example.com",
+		CurrentUser:    "testuser",
+		Accounts: []types.Account{
+			{
+				Endpoint: "https://test.example.com",
+				Users:    []types.User{{Username: "testuser", Token: token, ExpiresIn: "1735689600"}},
+			},
+		},
+	}
+}
+
+func assertPrivateFile(t *testing.T, filename string) {
+	t.Helper()
+	info, err := os.Stat(filename)
+	if err != nil {
+		t.Fatalf("Failed to stat %s: %v", filename, err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("%s permissions = %o, want 600", filename, perm)
+	}
+}
+
+func TestFileCredentialStore(t *testing.T) {
+	tests := []struct {
+		name       string
+		passphrase string
+	}{
+		{name: "plain file store"},
+		{name: "encrypted file store", passphrase: "correct horse battery staple"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(CredentialsPassphraseEnv, tt.passphrase)
+			configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+
+			err := CreateNewLitmusCtlConfig(configPath, newTokenConfig("secret-token"))
+			if err != nil {
+				t.Fatalf("CreateNewLitmusCtlConfig() error = %v", err)
+			}
+
+			raw, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+			if strings.Contains(string(raw), "secret-token") {
+				t.Errorf("Config file holds the raw token:\n%s", raw)
+			}
+			if !strings.Contains(string(raw), "tokenRef: file:testuser@https://test.example.com") {
+				t.Errorf("Config file has no token reference:\n%s", raw)
+			}
+			assertPrivateFile(t, configPath)
+			assertPrivateFile(t, configPath+credentialsFileSuffix)
+
+			stored, err := os.ReadFile(configPath + credentialsFileSuffix)
+			if err != nil {
+				t.Fatalf("Failed to read credential store: %v", err)
+			}
+			if encrypted := tt.passphrase != ""; encrypted == strings.Contains(string(stored), "secret-token") {
+				t.Errorf("Credential store encryption = %v, but content is:\n%s", encrypted, stored)
+			}
+
+			cfg, err := YamltoObject(configPath)
+			if err != nil {
+				t.Fatalf("YamltoObject() error = %v", err)
+			}
+			if got := cfg.Accounts[0].Users[0].Token; got != "secret-token" {
+				t.Errorf("Loaded token = %q, want %q", got, "secret-token")
+			}
+
+			if tt.passphrase != "" {
+				t.Setenv(CredentialsPassphraseEnv, "wrong")
+				if _, err := YamltoObject(configPath); err == nil {
+					t.Error("Expected error when unlocking with a wrong passphrase")
+				}
+				t.Setenv(CredentialsPassphraseEnv, "")
+				if _, err := YamltoObject(configPath); err == nil {
+					t.Error("Expected error when the passphrase is missing")
+				}
+			}
+		})
+	}
+}
+
+func TestStoreTokensOnlyWritesChangedTokens(t *testing.T) {
+	// The encrypted store is saved with a new salt, so any write changes it
+	t.Setenv(CredentialsPassphraseEnv, "correct horse battery staple")
+	configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+	storePath := configPath + credentialsFileSuffix
+
+	config := newTokenConfig("secret-token")
+	config.Accounts[0].Users = append(config.Accounts[0].Users, types.User{Username: "dev", Token: "dev-token"})
+	if err := CreateNewLitmusCtlConfig(configPath, config); err != nil {
+		t.Fatalf("CreateNewLitmusCtlConfig() error = %v", err)
+	}
+	stored, err := os.ReadFile(storePath)
+	if err != nil {
+		t.Fatalf("Failed to read credential store: %v", err)
+	}
+
+	// As in a new process, the tokens are only known once loaded
+	storedTokensMu.Lock()
+	storedTokens = map[string]string{}
+	storedTokensMu.Unlock()
+
+	if err := UpdateCurrent(types.Current{CurrentAccount: "https://test.example.com", CurrentUser: "dev"}, configPath); err != nil {
+		t.Fatalf("UpdateCurrent() error = %v", err)
+	}
+	unchanged, err := os.ReadFile(storePath)
+	if err != nil {
+		t.Fatalf("Failed to read credential store: %v", err)
+	}
+	if string(unchanged) != string(stored) {
+		t.Error("Credential store written again without any token change")
+	}
+
+	renewed := types.User{Username: "dev", Token: "renewed-token", ExpiresIn: "1735689600"}
+	if err := UpdateUserToken("https://test.example.com", renewed, configPath); err != nil {
+		t.Fatalf("UpdateUserToken() error = %v", err)
+	}
+	changed, err := os.ReadFile(storePath)
+	if err != nil {
+		t.Fatalf("Failed to read credential store: %v", err)
+	}
+	if string(changed) == string(stored) {
+		t.Error("Credential store not written with the renewed token")
+	}
+
+	cfg, err := YamltoObject(configPath)
+	if err != nil {
+		t.Fatalf("YamltoObject() error = %v", err)
+	}
+	if got := cfg.Accounts[0].Users[0].Token; got != "secret-token" {
+		t.Errorf("Token of testuser = %q, want %q", got, "secret-token")
+	}
+	if got := cfg.Accounts[0].Users[1].Token; got != "renewed-token" {
+		t.Errorf("Token of dev = %q, want %q", got, "renewed-token")
+	}
+}
+
+func TestSecurityAddPassword(t *testing.T) {
+	cmd := securityAddPassword("https://litmus.example.com/admin's", "secret-token")
+	for _, arg := range cmd.Args {
+		if strings.Contains(arg, "secret-token") {
+			t.Errorf("security arguments %q hold the secret", cmd.Args)
+		}
+	}
+
+	stdin, err := io.ReadAll(cmd.Stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "add-generic-password -U -s 'litmusctl' -a 'https://litmus.example.com/admin'\"'\"'s' -X " + hex.EncodeToString([]byte("secret-token")) + "\n"
+	if string(stdin) != want {
+		t.Errorf("security stdin = %q, want %q", stdin, want)
+	}
+}
+
+func TestNewCredentialStoreUnknown(t *testing.T) {
+	if _, err := NewCredentialStore("vault", "config.yaml"); err == nil {
+		t.Error("Expected error for an unknown credential store")
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// lockFileSuffix names the file locked while the config file is updated. The
+// config file itself can't be locked, as it is replaced on every write.
+const lockFileSuffix = ".lock"
+
+// withConfigLock runs fn while holding the lock of the config file, so that
+// concurrent litmusctl processes don't overwrite each other's changes
+func withConfigLock(filename string, fn func() error) error {
+	file, err := os.OpenFile(filename+lockFileSuffix, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return err
+	}
+	defer unlockFile(file)
+
+	return fn()
+}
+
+// writePrivateFile replaces the file with data, readable and writable only by
+// its owner. The data is written to a temporary file which is then renamed, so
+// that readers never see a partially written file.
+func writePrivateFile(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// os.CreateTemp already creates the file with 0600
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
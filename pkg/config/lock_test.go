@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+func TestConcurrentWriters(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test_config.yaml")
+
+	err := CreateNewLitmusCtlConfig(configPath, types.LitmuCtlConfig{
+		APIVersion: CurrentAPIVersion,
+		Kind:       "Config",
+		Accounts: []types.Account{
+			{
+				Endpoint: "https://litmus.example.com",
+				Users:    []types.User{{Username: "admin", Token: "admin-token"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create initial config: %v", err)
+	}
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			username := fmt.Sprintf("user-%d", i)
+			errs <- UpdateLitmusCtlConfig(types.UpdateLitmusCtlConfig{
+				Account: types.Account{
+					Endpoint: "https://litmus.example.com",
+					Users:    []types.User{{Username: username, Token: username + "-token"}},
+				},
+				CurrentAccount: "https://litmus.example.com",
+				CurrentUser:    username,
+			}, configPath)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("UpdateLitmusCtlConfig() error = %v", err)
+		}
+	}
+
+	cfg, err := YamltoObject(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if len(cfg.Accounts) != 1 || len(cfg.Accounts[0].Users) != writers+1 {
+		t.Fatalf("Accounts = %+v, want admin and %d users", cfg.Accounts, writers)
+	}
+	for i := 0; i < writers; i++ {
+		username := fmt.Sprintf("user-%d", i)
+		user, ok := FindUser(cfg, username, "https://litmus.example.com")
+		if !ok || user.Token != username+"-token" {
+			t.Errorf("User %s lost: got %+v", username, user)
+		}
+	}
+
+	if runtime.GOOS != "windows" {
+		for _, name := range []string{configPath, configPath + credentialsFileSuffix} {
+			info, err := os.Stat(name)
+			if err != nil {
+				t.Fatalf("Failed to stat %s: %v", name, err)
+			}
+			if perm := info.Mode().Perm(); perm != 0600 {
+				t.Errorf("%s permissions = %o, want 600", name, perm)
+			}
+		}
+	}
+
+	tmpFiles, err := filepath.Glob(filepath.Join(tmpDir, "*.tmp*"))
+	if err != nil || len(tmpFiles) != 0 {
+		t.Errorf("Temporary files left behind: %v, %v", tmpFiles, err)
+	}
+}
@@ -18,34 +18,35 @@ package config
 import (
 	"errors"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/litmuschaos/litmusctl/pkg/types"
 	"gopkg.in/yaml.v2"
 )
 
 var (
-	SkipSSLVerify bool   = false
-	CACert        string = ""
+	SkipSSLVerify  bool          = false
+	CACert         string        = ""
+	RequestTimeout time.Duration = 0
+	RetryCount     int           = 3
+	RetryMaxWait   time.Duration = 10 * time.Second
+	RetryMutations bool          = false
+	// ClientCert and ClientKey authenticate litmusctl with mutual TLS
+	ClientCert string = ""
+	ClientKey  string = ""
+	// Proxy is the HTTP proxy used to reach the portal, the one of the
+	// HTTPS_PROXY and HTTP_PROXY environment variables when empty
+	Proxy string = ""
+	// KubeContext is the kubeconfig context of the active litmusctl context,
+	// the current one of the kubeconfig file when empty
+	KubeContext string = ""
 )
 
 func CreateNewLitmusCtlConfig(filename string, config types.LitmuCtlConfig) error {
-
-	configByte, err := yaml.Marshal(config)
-	if err != nil {
-		return err
-	}
-
-	_, err = os.OpenFile(filename, os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return err
-	}
-
-	err = os.WriteFile(filename, configByte, 0644)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return withConfigLock(filename, func() error {
+		return writeObjToFile(config, filename)
+	})
 }
 
 func FileExists(filename string) bool {
@@ -67,18 +68,34 @@ func GetFileLength(filename string) (int, error) {
 }
 
 func YamltoObject(filename string) (types.LitmuCtlConfig, error) {
+	obj, err := ReadConfig(filename)
+	if err != nil {
+		return types.LitmuCtlConfig{}, err
+	}
+
+	err = loadTokens(&obj, filename)
+	if err != nil {
+		return types.LitmuCtlConfig{}, err
+	}
+
+	return obj, nil
+}
+
+// ReadConfig reads the config file without loading the tokens of its users
+// from their credential stores, for the commands that don't need them
+func ReadConfig(filename string) (types.LitmuCtlConfig, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return types.LitmuCtlConfig{}, errors.New("File reading error " + err.Error())
 	}
 
-	obj := &types.LitmuCtlConfig{}
-	err = yaml.Unmarshal(data, obj)
+	obj := types.LitmuCtlConfig{}
+	err = yaml.Unmarshal(data, &obj)
 	if err != nil {
 		return types.LitmuCtlConfig{}, errors.New("File format not correct " + err.Error())
 	}
 
-	return *obj, nil
+	return obj, nil
 }
 
 func ConfigSyntaxCheck(filename string) error {
@@ -88,7 +105,7 @@ func ConfigSyntaxCheck(filename string) error {
 		return err
 	}
 
-	if obj.APIVersion != "v1" || obj.Kind != "Config" {
+	if !IsKnownAPIVersion(obj.APIVersion) || obj.Kind != "Config" {
 		return errors.New("File format not correct")
 	}
 
@@ -96,66 +113,108 @@ func ConfigSyntaxCheck(filename string) error {
 }
 
 func UpdateLitmusCtlConfig(litmusconfig types.UpdateLitmusCtlConfig, filename string) error {
-	obj, err := YamltoObject(filename)
-	if err != nil {
-		return err
-	}
+	return withConfigLock(filename, func() error {
+		obj, err := YamltoObject(filename)
+		if err != nil {
+			return err
+		}
 
-	var outerflag = false
-	for i, act := range obj.Accounts {
-		if act.Endpoint == litmusconfig.Account.Endpoint {
-			var innerflag = false
-			obj.Accounts[i].ServerEndpoint = litmusconfig.ServerEndpoint
-			for j, user := range act.Users {
-				if user.Username == litmusconfig.Account.Users[0].Username {
-					obj.Accounts[i].Users[j].Username = litmusconfig.Account.Users[0].Username
-					obj.Accounts[i].Users[j].Token = litmusconfig.Account.Users[0].Token
-					obj.Accounts[i].Users[j].ExpiresIn = litmusconfig.Account.Users[0].ExpiresIn
-					innerflag, outerflag = true, true
+		var outerflag = false
+		for i, act := range obj.Accounts {
+			if act.Endpoint == litmusconfig.Account.Endpoint {
+				var innerflag = false
+				obj.Accounts[i].ServerEndpoint = litmusconfig.ServerEndpoint
+				obj.Accounts[i].InsecureSkipVerify = litmusconfig.Account.InsecureSkipVerify
+				obj.Accounts[i].CACert = litmusconfig.Account.CACert
+				obj.Accounts[i].ClientCert = litmusconfig.Account.ClientCert
+				obj.Accounts[i].ClientKey = litmusconfig.Account.ClientKey
+				obj.Accounts[i].Proxy = litmusconfig.Account.Proxy
+				for j, user := range act.Users {
+					if user.Username == litmusconfig.Account.Users[0].Username {
+						obj.Accounts[i].Users[j].Username = litmusconfig.Account.Users[0].Username
+						obj.Accounts[i].Users[j].Token = litmusconfig.Account.Users[0].Token
+						obj.Accounts[i].Users[j].ExpiresIn = litmusconfig.Account.Users[0].ExpiresIn
+						obj.Accounts[i].Users[j].CredentialSource = litmusconfig.Account.Users[0].CredentialSource
+						innerflag, outerflag = true, true
+					}
 				}
-			}
 
-			if !innerflag {
-				obj.Accounts[i].Users = append(obj.Accounts[i].Users, litmusconfig.Account.Users[0])
-				outerflag = true
+				if !innerflag {
+					obj.Accounts[i].Users = append(obj.Accounts[i].Users, litmusconfig.Account.Users[0])
+					outerflag = true
+				}
 			}
 		}
-	}
 
-	if !outerflag {
-		obj.Accounts = append(obj.Accounts, litmusconfig.Account)
-	}
+		if !outerflag {
+			obj.Accounts = append(obj.Accounts, litmusconfig.Account)
+		}
 
-	obj.CurrentAccount = litmusconfig.CurrentAccount
-	obj.CurrentUser = litmusconfig.CurrentUser
+		obj.CurrentAccount = litmusconfig.CurrentAccount
+		obj.CurrentUser = litmusconfig.CurrentUser
+		leaveStaleContext(&obj)
+		if litmusconfig.CredentialStore != "" {
+			obj.CredentialStore = litmusconfig.CredentialStore
+		}
 
-	err = writeObjToFile(obj, filename)
-	if err != nil {
-		return err
-	}
+		err = writeObjToFile(obj, filename)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return nil
+	})
+}
+
+// UpdateUserToken stores the renewed token of a user without changing the current account
+func UpdateUserToken(endpoint string, user types.User, filename string) error {
+	return withConfigLock(filename, func() error {
+		obj, err := YamltoObject(filename)
+		if err != nil {
+			return err
+		}
+
+		for i, act := range obj.Accounts {
+			if act.Endpoint != endpoint {
+				continue
+			}
+			for j, u := range act.Users {
+				if u.Username == user.Username {
+					obj.Accounts[i].Users[j].Token = user.Token
+					obj.Accounts[i].Users[j].ExpiresIn = user.ExpiresIn
+					return writeObjToFile(obj, filename)
+				}
+			}
+		}
+
+		return errors.New("user " + user.Username + " not found for account " + endpoint)
+	})
 }
 
 func UpdateCurrent(current types.Current, filename string) error {
-	obj, err := YamltoObject(filename)
-	if err != nil {
-		return err
-	}
+	return withConfigLock(filename, func() error {
+		obj, err := YamltoObject(filename)
+		if err != nil {
+			return err
+		}
 
-	obj.CurrentUser = current.CurrentUser
-	obj.CurrentAccount = current.CurrentAccount
+		obj.CurrentUser = current.CurrentUser
+		obj.CurrentAccount = current.CurrentAccount
+		leaveStaleContext(&obj)
 
-	err = writeObjToFile(obj, filename)
-	if err != nil {
-		return err
-	}
+		err = writeObjToFile(obj, filename)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return nil
+	})
 }
 
+// writeObjToFile moves the tokens of obj into the credential store and writes
+// the rest of it to the config file
 func writeObjToFile(obj types.LitmuCtlConfig, filename string) error {
-	_, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY, 0644)
+	obj, err := storeTokens(obj, filename)
 	if err != nil {
 		return err
 	}
@@ -165,12 +224,7 @@ func writeObjToFile(obj types.LitmuCtlConfig, filename string) error {
 		return err
 	}
 
-	err = os.WriteFile(filename, byteObj, 0644)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return writePrivateFile(filename, byteObj)
 }
 
 func IsAccountExists(obj types.LitmuCtlConfig, username string, endpoint string) bool {
@@ -186,3 +240,269 @@ func IsAccountExists(obj types.LitmuCtlConfig, username string, endpoint string)
 
 	return false
 }
+
+// FindAccount returns the account at endpoint
+func FindAccount(obj types.LitmuCtlConfig, endpoint string) (types.Account, bool) {
+	for _, account := range obj.Accounts {
+		if account.Endpoint == endpoint {
+			return account, true
+		}
+	}
+
+	return types.Account{}, false
+}
+
+// FindUser returns the user with the given username of the account at endpoint
+func FindUser(obj types.LitmuCtlConfig, username string, endpoint string) (types.User, bool) {
+	for _, account := range obj.Accounts {
+		if account.Endpoint == endpoint {
+			for _, user := range account.Users {
+				if username == user.Username {
+					return user, true
+				}
+			}
+		}
+	}
+
+	return types.User{}, false
+}
+
+// FindContext returns the context with the given name
+func FindContext(obj types.LitmuCtlConfig, name string) (types.Context, bool) {
+	for _, context := range obj.Contexts {
+		if context.Name == name {
+			return context, true
+		}
+	}
+
+	return types.Context{}, false
+}
+
+// SetContext adds the context to the config file, replacing the one with the
+// same name. Its account and user must already exist.
+func SetContext(context types.Context, filename string) error {
+	return withConfigLock(filename, func() error {
+		obj, err := YamltoObject(filename)
+		if err != nil {
+			return err
+		}
+
+		if !IsAccountExists(obj, context.Username, context.Endpoint) {
+			return errors.New("account " + context.Username + " at " + context.Endpoint + " not found, add it with `litmusctl config set-account`")
+		}
+
+		replaced := false
+		for i, c := range obj.Contexts {
+			if c.Name == context.Name {
+				obj.Contexts[i] = context
+				replaced = true
+			}
+		}
+		if !replaced {
+			obj.Contexts = append(obj.Contexts, context)
+		}
+
+		// The current account follows the current context
+		if obj.CurrentContext == context.Name {
+			obj.CurrentAccount = context.Endpoint
+			obj.CurrentUser = context.Username
+		}
+
+		return writeObjToFile(obj, filename)
+	})
+}
+
+// UseContext makes the named context current, along with its account and user
+func UseContext(name string, filename string) error {
+	return withConfigLock(filename, func() error {
+		obj, err := YamltoObject(filename)
+		if err != nil {
+			return err
+		}
+
+		context, ok := FindContext(obj, name)
+		if !ok {
+			return errors.New("context " + name + " not found")
+		}
+
+		obj.CurrentContext = context.Name
+		obj.CurrentAccount = context.Endpoint
+		obj.CurrentUser = context.Username
+
+		return writeObjToFile(obj, filename)
+	})
+}
+
+// leaveStaleContext unsets the current context once the current account or
+// user no longer matches it
+func leaveStaleContext(obj *types.LitmuCtlConfig) {
+	context, ok := FindContext(*obj, obj.CurrentContext)
+	if !ok || context.Endpoint != obj.CurrentAccount || context.Username != obj.CurrentUser {
+		obj.CurrentContext = ""
+	}
+}
+
+// DeleteAccount removes the account at endpoint, or only one of its users when
+// username is set, along with their tokens and contexts. Tokens aren't loaded,
+// so that accounts whose token is missing from its store can be removed.
+func DeleteAccount(endpoint string, username string, filename string) error {
+	return withConfigLock(filename, func() error {
+		obj, err := ReadConfig(filename)
+		if err != nil {
+			return err
+		}
+
+		removed := removeUsers(&obj, func(account types.Account, user types.User) bool {
+			return account.Endpoint == endpoint && (username == "" || user.Username == username)
+		})
+		if len(removed) == 0 {
+			if username != "" {
+				return errors.New("user " + username + " not found for account " + endpoint)
+			}
+			return errors.New("account " + endpoint + " not found")
+		}
+
+		err = writeObjToFile(obj, filename)
+		if err != nil {
+			return err
+		}
+
+		return deleteTokens(removed, filename)
+	})
+}
+
+// PruneExpiredUsers removes the users whose token has expired and returns
+// them as username@endpoint. Users with a credential source are kept, as their
+// token gets renewed on the next command.
+func PruneExpiredUsers(filename string, now time.Time) ([]string, error) {
+	var pruned []string
+	err := withConfigLock(filename, func() error {
+		obj, err := ReadConfig(filename)
+		if err != nil {
+			return err
+		}
+
+		removed := removeUsers(&obj, func(account types.Account, user types.User) bool {
+			expiresIn, err := strconv.ParseInt(user.ExpiresIn, 10, 64)
+			if err != nil || user.CredentialSource != nil || time.Unix(expiresIn, 0).After(now) {
+				return false
+			}
+
+			pruned = append(pruned, tokenKey(account.Endpoint, user.Username))
+			return true
+		})
+		if len(removed) == 0 {
+			return nil
+		}
+
+		err = writeObjToFile(obj, filename)
+		if err != nil {
+			return err
+		}
+
+		return deleteTokens(removed, filename)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pruned, nil
+}
+
+// RenameAccount moves the account at endpoint to newEndpoint, along with its
+// tokens and contexts. The server endpoint is replaced when serverEndpoint is set.
+func RenameAccount(endpoint string, newEndpoint string, serverEndpoint string, filename string) error {
+	return withConfigLock(filename, func() error {
+		obj, err := ReadConfig(filename)
+		if err != nil {
+			return err
+		}
+
+		var movedUsers []types.User
+		found := false
+		for i, account := range obj.Accounts {
+			if account.Endpoint == newEndpoint && newEndpoint != endpoint {
+				return errors.New("account " + newEndpoint + " already exists")
+			}
+			if account.Endpoint == endpoint {
+				// The tokens that can be loaded are stored again under the
+				// new endpoint, the others keep their reference
+				for j, user := range account.Users {
+					if user.TokenRef == "" || newEndpoint == endpoint {
+						continue
+					}
+					token, err := loadToken(user.TokenRef, filename)
+					if err != nil {
+						continue
+					}
+					obj.Accounts[i].Users[j].Token = token
+					movedUsers = append(movedUsers, user)
+				}
+				obj.Accounts[i].Endpoint = newEndpoint
+				if serverEndpoint != "" {
+					obj.Accounts[i].ServerEndpoint = serverEndpoint
+				}
+				found = true
+			}
+		}
+		if !found {
+			return errors.New("account " + endpoint + " not found")
+		}
+
+		for i, context := range obj.Contexts {
+			if context.Endpoint == endpoint {
+				obj.Contexts[i].Endpoint = newEndpoint
+			}
+		}
+		if obj.CurrentAccount == endpoint {
+			obj.CurrentAccount = newEndpoint
+		}
+
+		err = writeObjToFile(obj, filename)
+		if err != nil {
+			return err
+		}
+
+		return deleteTokens(movedUsers, filename)
+	})
+}
+
+// removeUsers removes the users matching remove, then the accounts left
+// without users and the contexts of the removed users. The current account
+// and user are unset when removed. It returns the removed users.
+func removeUsers(obj *types.LitmuCtlConfig, remove func(account types.Account, user types.User) bool) []types.User {
+	var removed []types.User
+	var accounts []types.Account
+	for _, account := range obj.Accounts {
+		var users []types.User
+		for _, user := range account.Users {
+			if remove(account, user) {
+				removed = append(removed, user)
+			} else {
+				users = append(users, user)
+			}
+		}
+
+		if len(users) > 0 {
+			account.Users = users
+			accounts = append(accounts, account)
+		}
+	}
+	obj.Accounts = accounts
+
+	var contexts []types.Context
+	for _, context := range obj.Contexts {
+		if IsAccountExists(*obj, context.Username, context.Endpoint) {
+			contexts = append(contexts, context)
+		}
+	}
+	obj.Contexts = contexts
+
+	if !IsAccountExists(*obj, obj.CurrentUser, obj.CurrentAccount) {
+		obj.CurrentAccount = ""
+		obj.CurrentUser = ""
+	}
+	leaveStaleContext(obj)
+
+	return removed
+}
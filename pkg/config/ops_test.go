@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/litmuschaos/litmusctl/pkg/types"
 )
@@ -154,6 +156,40 @@ func TestYamltoObject(t *testing.T) {
 	}
 }
 
+func TestReadConfigWithoutTokens(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+	content := `apiVersion: v2
+kind: Config
+current-account: https://litmus.example.com
+current-user: admin
+accounts:
+  - endpoint: https://litmus.example.com
+    users:
+      - username: admin
+        tokenRef: file:admin@https://litmus.example.com
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	// The credential store is encrypted, and no passphrase is set
+	if err := os.WriteFile(configPath+credentialsFileSuffix, []byte(`{"salt":"c2FsdA==","nonce":"bm9uY2U=","encrypted":"ZGF0YQ=="}`), 0600); err != nil {
+		t.Fatalf("Failed to write credentials: %v", err)
+	}
+	t.Setenv(CredentialsPassphraseEnv, "")
+
+	if _, err := YamltoObject(configPath); err == nil || !strings.Contains(err.Error(), CredentialsPassphraseEnv) {
+		t.Fatalf("YamltoObject() error = %v, want the passphrase to be missing", err)
+	}
+
+	obj, err := ReadConfig(configPath)
+	if err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+	if user := obj.Accounts[0].Users[0]; user.Username != "admin" || user.Token != "" || user.TokenRef == "" {
+		t.Errorf("ReadConfig() user = %+v, want the token reference without the token", user)
+	}
+}
+
 func TestConfigSyntaxCheck(t *testing.T) {
 	testdataDir := filepath.Join("testdata")
 
@@ -499,3 +535,458 @@ func TestUpdateCurrent(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateUserToken(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "update_user_token_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "test_config.yaml")
+
+	source := &types.CredentialSource{PasswordFile: "/run/secrets/litmus"}
+	initialConfig := types.LitmuCtlConfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentAccount: "https://current.example.com",
+		CurrentUser:    "admin",
+		Accounts: []types.Account{
+			{
+				Endpoint: "https://current.example.com",
+				Users:    []types.User{{Username: "admin", Token: "token"}},
+			},
+			{
+				Endpoint: "https://other.example.com",
+				Users:    []types.User{{Username: "ci", Token: "old-token", ExpiresIn: "1", CredentialSource: source}},
+			},
+		},
+	}
+
+	err = CreateNewLitmusCtlConfig(configPath, initialConfig)
+	if err != nil {
+		t.Fatalf("Failed to create initial config: %v", err)
+	}
+
+	t.Run("renew token of a user", func(t *testing.T) {
+		user := types.User{Username: "ci", Token: "new-token", ExpiresIn: "1800000000"}
+
+		err := UpdateUserToken("https://other.example.com", user, configPath)
+		if err != nil {
+			t.Fatalf("UpdateUserToken() error = %v", err)
+		}
+
+		cfg, err := YamltoObject(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		got := cfg.Accounts[1].Users[0]
+		if got.Token != "new-token" || got.ExpiresIn != "1800000000" {
+			t.Errorf("Token not renewed: got %q expiring at %q", got.Token, got.ExpiresIn)
+		}
+		if got.CredentialSource == nil || *got.CredentialSource != *source {
+			t.Errorf("CredentialSource changed: got %+v, want %+v", got.CredentialSource, source)
+		}
+		if cfg.CurrentAccount != "https://current.example.com" || cfg.CurrentUser != "admin" {
+			t.Errorf("Current account changed: got %q/%q", cfg.CurrentAccount, cfg.CurrentUser)
+		}
+	})
+
+	t.Run("error on unknown user", func(t *testing.T) {
+		err := UpdateUserToken("https://other.example.com", types.User{Username: "unknown"}, configPath)
+		if err == nil {
+			t.Error("Expected error for unknown user")
+		}
+	})
+}
+
+func TestContexts(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "contexts_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "test_config.yaml")
+
+	initialConfig := types.LitmuCtlConfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentAccount: "https://dev.example.com",
+		CurrentUser:    "admin",
+		Accounts: []types.Account{
+			{
+				Endpoint: "https://dev.example.com",
+				Users:    []types.User{{Username: "admin", Token: "token"}},
+			},
+			{
+				Endpoint: "https://prod.example.com",
+				Users:    []types.User{{Username: "ci", Token: "token2"}},
+			},
+		},
+	}
+
+	err = CreateNewLitmusCtlConfig(configPath, initialConfig)
+	if err != nil {
+		t.Fatalf("Failed to create initial config: %v", err)
+	}
+
+	prod := types.Context{
+		Name:      "prod",
+		Endpoint:  "https://prod.example.com",
+		Username:  "ci",
+		ProjectID: "project-1",
+		InfraID:   "infra-1",
+	}
+
+	t.Run("set a context", func(t *testing.T) {
+		if err := SetContext(prod, configPath); err != nil {
+			t.Fatalf("SetContext() error = %v", err)
+		}
+
+		updated := prod
+		updated.ProjectID = "project-2"
+		if err := SetContext(updated, configPath); err != nil {
+			t.Fatalf("SetContext() error = %v", err)
+		}
+
+		cfg, err := YamltoObject(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+		if len(cfg.Contexts) != 1 || cfg.Contexts[0] != updated {
+			t.Errorf("Contexts = %+v, want only %+v", cfg.Contexts, updated)
+		}
+		if cfg.CurrentContext != "" || cfg.CurrentAccount != "https://dev.example.com" {
+			t.Errorf("Current context changed: got %q at %q", cfg.CurrentContext, cfg.CurrentAccount)
+		}
+	})
+
+	t.Run("error on unknown account", func(t *testing.T) {
+		err := SetContext(types.Context{Name: "other", Endpoint: "https://prod.example.com", Username: "admin"}, configPath)
+		if err == nil {
+			t.Error("Expected error for unknown account")
+		}
+	})
+
+	t.Run("use a context", func(t *testing.T) {
+		if err := UseContext("prod", configPath); err != nil {
+			t.Fatalf("UseContext() error = %v", err)
+		}
+
+		cfg, err := YamltoObject(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+		if cfg.CurrentContext != "prod" || cfg.CurrentAccount != "https://prod.example.com" || cfg.CurrentUser != "ci" {
+			t.Errorf("Current context not switched: got %q with %q/%q", cfg.CurrentContext, cfg.CurrentAccount, cfg.CurrentUser)
+		}
+	})
+
+	t.Run("error on unknown context", func(t *testing.T) {
+		if err := UseContext("unknown", configPath); err == nil {
+			t.Error("Expected error for unknown context")
+		}
+	})
+
+	t.Run("switching account leaves the context", func(t *testing.T) {
+		err := UpdateCurrent(types.Current{CurrentAccount: "https://dev.example.com", CurrentUser: "admin"}, configPath)
+		if err != nil {
+			t.Fatalf("UpdateCurrent() error = %v", err)
+		}
+
+		cfg, err := YamltoObject(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+		if cfg.CurrentContext != "" {
+			t.Errorf("CurrentContext = %q, want it unset", cfg.CurrentContext)
+		}
+		if _, ok := FindContext(cfg, "prod"); !ok {
+			t.Error("Context prod was removed")
+		}
+	})
+}
+
+func TestDeleteAccount(t *testing.T) {
+	tests := []struct {
+		name         string
+		endpoint     string
+		username     string
+		wantAccounts int
+		wantCurrent  bool
+		wantContexts int
+		removedKey   string
+		wantErr      bool
+	}{
+		{
+			name:         "delete a user of the current account",
+			endpoint:     "https://current.example.com",
+			username:     "dev",
+			wantAccounts: 2,
+			wantCurrent:  true,
+			wantContexts: 1,
+			removedKey:   "dev@https://current.example.com",
+		},
+		{
+			name:         "delete the current user",
+			endpoint:     "https://current.example.com",
+			username:     "admin",
+			wantAccounts: 2,
+			wantContexts: 1,
+			removedKey:   "admin@https://current.example.com",
+		},
+		{
+			name:         "delete the current account",
+			endpoint:     "https://current.example.com",
+			wantAccounts: 1,
+			wantContexts: 0,
+			removedKey:   "dev@https://current.example.com",
+		},
+		{
+			name:         "delete another account",
+			endpoint:     "https://other.example.com",
+			wantAccounts: 1,
+			wantCurrent:  true,
+			wantContexts: 2,
+			removedKey:   "ci@https://other.example.com",
+		},
+		{
+			name:     "error on unknown user",
+			endpoint: "https://other.example.com",
+			username: "unknown",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+			err := CreateNewLitmusCtlConfig(configPath, types.LitmuCtlConfig{
+				APIVersion:     "v1",
+				Kind:           "Config",
+				CurrentAccount: "https://current.example.com",
+				CurrentUser:    "admin",
+				Accounts: []types.Account{
+					{
+						Endpoint: "https://current.example.com",
+						Users:    []types.User{{Username: "admin", Token: "token"}, {Username: "dev", Token: "dev-token"}},
+					},
+					{
+						Endpoint: "https://other.example.com",
+						Users:    []types.User{{Username: "ci", Token: "ci-token"}},
+					},
+				},
+				Contexts: []types.Context{
+					{Name: "admin", Endpoint: "https://current.example.com", Username: "admin"},
+					{Name: "dev", Endpoint: "https://current.example.com", Username: "dev"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create initial config: %v", err)
+			}
+
+			err = DeleteAccount(tt.endpoint, tt.username, configPath)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error for unknown user")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteAccount() error = %v", err)
+			}
+
+			cfg, err := YamltoObject(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read updated config: %v", err)
+			}
+			if len(cfg.Accounts) != tt.wantAccounts {
+				t.Errorf("Accounts = %+v, want %d accounts", cfg.Accounts, tt.wantAccounts)
+			}
+			if len(cfg.Contexts) != tt.wantContexts {
+				t.Errorf("Contexts = %+v, want %d contexts", cfg.Contexts, tt.wantContexts)
+			}
+			if tt.wantCurrent && (cfg.CurrentAccount != "https://current.example.com" || cfg.CurrentUser != "admin") {
+				t.Errorf("Current account changed: got %q/%q", cfg.CurrentAccount, cfg.CurrentUser)
+			}
+			if !tt.wantCurrent && (cfg.CurrentAccount != "" || cfg.CurrentUser != "") {
+				t.Errorf("Current account not unset: got %q/%q", cfg.CurrentAccount, cfg.CurrentUser)
+			}
+
+			store, _ := NewCredentialStore(FileCredentialStore, configPath)
+			if _, err := store.Get(tt.removedKey); err == nil {
+				t.Errorf("Token of %s left in the credential store", tt.removedKey)
+			}
+		})
+	}
+}
+
+func TestDeleteAccountWithMissingToken(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+	err := CreateNewLitmusCtlConfig(configPath, types.LitmuCtlConfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentAccount: "https://current.example.com",
+		CurrentUser:    "admin",
+		Accounts: []types.Account{
+			{
+				Endpoint: "https://current.example.com",
+				Users:    []types.User{{Username: "admin", Token: "token"}, {Username: "dev", Token: "dev-token"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create initial config: %v", err)
+	}
+
+	// The token of dev was removed from the store outside litmusctl
+	store, _ := NewCredentialStore(FileCredentialStore, configPath)
+	if err := store.Delete(tokenKey("https://current.example.com", "dev")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := YamltoObject(configPath); err == nil {
+		t.Fatal("YamltoObject() with a missing token succeeded, want an error")
+	}
+
+	if err := DeleteAccount("https://current.example.com", "admin", configPath); err != nil {
+		t.Fatalf("DeleteAccount() of another user error = %v", err)
+	}
+	if err := DeleteAccount("https://current.example.com", "dev", configPath); err != nil {
+		t.Fatalf("DeleteAccount() of the user with a missing token error = %v", err)
+	}
+
+	cfg, err := YamltoObject(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read updated config: %v", err)
+	}
+	if len(cfg.Accounts) != 0 {
+		t.Errorf("Accounts = %+v, want none", cfg.Accounts)
+	}
+}
+
+func TestPruneExpiredUsers(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+
+	err := CreateNewLitmusCtlConfig(configPath, types.LitmuCtlConfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentAccount: "https://expired.example.com",
+		CurrentUser:    "admin",
+		Accounts: []types.Account{
+			{
+				Endpoint: "https://expired.example.com",
+				Users:    []types.User{{Username: "admin", Token: "token", ExpiresIn: "1600000000"}},
+			},
+			{
+				Endpoint: "https://mixed.example.com",
+				Users: []types.User{
+					{Username: "valid", Token: "token", ExpiresIn: "1800000000"},
+					{Username: "renewable", Token: "token", ExpiresIn: "1600000000", CredentialSource: &types.CredentialSource{PasswordEnv: "PASSWORD"}},
+					{Username: "expired", Token: "token", ExpiresIn: "1600000000"},
+					{Username: "no-expiry", Token: "token"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create initial config: %v", err)
+	}
+
+	pruned, err := PruneExpiredUsers(configPath, now)
+	if err != nil {
+		t.Fatalf("PruneExpiredUsers() error = %v", err)
+	}
+	want := []string{"admin@https://expired.example.com", "expired@https://mixed.example.com"}
+	if len(pruned) != len(want) || pruned[0] != want[0] || pruned[1] != want[1] {
+		t.Errorf("PruneExpiredUsers() = %v, want %v", pruned, want)
+	}
+
+	cfg, err := YamltoObject(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read updated config: %v", err)
+	}
+	if len(cfg.Accounts) != 1 || len(cfg.Accounts[0].Users) != 3 {
+		t.Errorf("Accounts = %+v, want the three users of mixed.example.com", cfg.Accounts)
+	}
+	if cfg.CurrentAccount != "" || cfg.CurrentUser != "" {
+		t.Errorf("Current account not unset: got %q/%q", cfg.CurrentAccount, cfg.CurrentUser)
+	}
+
+	pruned, err = PruneExpiredUsers(configPath, now)
+	if err != nil || len(pruned) != 0 {
+		t.Errorf("PruneExpiredUsers() = %v, %v, want nothing to prune", pruned, err)
+	}
+}
+
+func TestRenameAccount(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+
+	err := CreateNewLitmusCtlConfig(configPath, types.LitmuCtlConfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentAccount: "https://old.example.com",
+		CurrentUser:    "admin",
+		Accounts: []types.Account{
+			{
+				Endpoint:       "https://old.example.com",
+				ServerEndpoint: "https://old.example.com",
+				Users:          []types.User{{Username: "admin", Token: "token"}},
+			},
+			{
+				Endpoint: "https://other.example.com",
+				Users:    []types.User{{Username: "ci", Token: "ci-token"}},
+			},
+		},
+		Contexts:       []types.Context{{Name: "prod", Endpoint: "https://old.example.com", Username: "admin"}},
+		CurrentContext: "prod",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create initial config: %v", err)
+	}
+
+	t.Run("error on existing endpoint", func(t *testing.T) {
+		if err := RenameAccount("https://old.example.com", "https://other.example.com", "", configPath); err == nil {
+			t.Error("Expected error for existing endpoint")
+		}
+	})
+
+	t.Run("error on unknown account", func(t *testing.T) {
+		if err := RenameAccount("https://unknown.example.com", "https://new.example.com", "", configPath); err == nil {
+			t.Error("Expected error for unknown account")
+		}
+	})
+
+	t.Run("rename account", func(t *testing.T) {
+		err := RenameAccount("https://old.example.com", "https://new.example.com", "https://server.example.com", configPath)
+		if err != nil {
+			t.Fatalf("RenameAccount() error = %v", err)
+		}
+
+		cfg, err := YamltoObject(configPath)
+		if err != nil {
+			t.Fatalf("Failed to read updated config: %v", err)
+		}
+
+		user, ok := FindUser(cfg, "admin", "https://new.example.com")
+		if !ok || user.Token != "token" {
+			t.Errorf("User not moved with its token: got %+v", user)
+		}
+		if cfg.Accounts[0].ServerEndpoint != "https://server.example.com" {
+			t.Errorf("ServerEndpoint not updated: got %q", cfg.Accounts[0].ServerEndpoint)
+		}
+		if cfg.CurrentAccount != "https://new.example.com" || cfg.CurrentUser != "admin" {
+			t.Errorf("Current account not updated: got %q/%q", cfg.CurrentAccount, cfg.CurrentUser)
+		}
+		if cfg.CurrentContext != "prod" || cfg.Contexts[0].Endpoint != "https://new.example.com" {
+			t.Errorf("Context not updated: got %q with %+v", cfg.CurrentContext, cfg.Contexts)
+		}
+
+		store, _ := NewCredentialStore(FileCredentialStore, configPath)
+		if _, err := store.Get(tokenKey("https://old.example.com", "admin")); err == nil {
+			t.Error("Token of the old endpoint left in the credential store")
+		}
+	})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+// RedactedValue replaces the secrets hidden from the output of litmusctl
+const RedactedValue = "REDACTED"
+
+// Redact returns a copy of obj with its tokens, inline client keys and proxy
+// passwords replaced by RedactedValue
+func Redact(obj types.LitmuCtlConfig) types.LitmuCtlConfig {
+	accounts := make([]types.Account, len(obj.Accounts))
+	for i, account := range obj.Accounts {
+		users := make([]types.User, len(account.Users))
+		for j, user := range account.Users {
+			// Tokens kept in a credential store may not be loaded
+			if user.Token != "" || user.TokenRef != "" {
+				user.Token = RedactedValue
+			}
+			users[j] = user
+		}
+		account.Users = users
+
+		if strings.Contains(account.ClientKey, "-----BEGIN") {
+			account.ClientKey = RedactedValue
+		}
+		account.Proxy = RedactURL(account.Proxy)
+		accounts[i] = account
+	}
+	obj.Accounts = accounts
+
+	return obj
+}
+
+// RedactURL replaces the password of the URL, if any, by RedactedValue
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL
+	}
+	if _, ok := u.User.Password(); !ok {
+		return rawURL
+	}
+	u.User = url.UserPassword(u.User.Username(), RedactedValue)
+
+	return u.String()
+}
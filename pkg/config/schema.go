@@ -0,0 +1,161 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"gopkg.in/yaml.v2"
+)
+
+// CurrentAPIVersion is the version of the config files written by litmusctl.
+//
+//   - v1 keeps the tokens in the config file
+//   - v2 keeps the tokens in a credential store, and adds credential sources,
+//     per account request settings and contexts
+const CurrentAPIVersion = "v2"
+
+// SkipMigrationAnnotation is set on the commands that read the config file
+// without migrating it first
+const SkipMigrationAnnotation = "litmusctl/skip-config-migration"
+
+// migration upgrades a config to the version to
+type migration struct {
+	to      string
+	migrate func(obj *types.LitmuCtlConfig) error
+}
+
+// migrations are keyed by the version they upgrade from
+var migrations = map[string]migration{
+	"v1": {to: "v2", migrate: migrateV1},
+}
+
+// migrateV1 upgrades a v1 config to v2. The new fields are optional, and the
+// tokens are moved to the credential store when the config is written.
+func migrateV1(obj *types.LitmuCtlConfig) error {
+	return nil
+}
+
+// IsKnownAPIVersion reports whether litmusctl can read config files of version
+func IsKnownAPIVersion(version string) bool {
+	_, ok := migrations[version]
+	return ok || version == CurrentAPIVersion
+}
+
+// Migrate upgrades the config file to CurrentAPIVersion, keeping a copy of the
+// previous one in a .<version>.bak file next to it, with its tokens redacted.
+// It returns the version the file was migrated from, empty when it was
+// already current.
+func Migrate(filename string) (string, error) {
+	var from string
+	err := withConfigLock(filename, func() error {
+		var err error
+		from, err = migrate(filename)
+		return err
+	})
+
+	return from, err
+}
+
+func migrate(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil || len(bytes.TrimSpace(data)) == 0 {
+		return "", err
+	}
+
+	// Reading the tokens is only needed when the file gets rewritten
+	var obj types.LitmuCtlConfig
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return "", errors.New("File format not correct " + err.Error())
+	}
+	from := obj.APIVersion
+	if from == CurrentAPIVersion && !HasPlaintextTokens(obj) {
+		return "", nil
+	}
+
+	obj, err = YamltoObject(filename)
+	if err != nil {
+		return "", err
+	}
+	if from == CurrentAPIVersion {
+		// Tokens written by older versions are moved out of the config file
+		return "", writeObjToFile(obj, filename)
+	}
+
+	for obj.APIVersion != CurrentAPIVersion {
+		m, ok := migrations[obj.APIVersion]
+		if !ok {
+			return "", errors.New("config file version " + obj.APIVersion + " is not supported by this litmusctl, supported versions are v1 to " + CurrentAPIVersion)
+		}
+		if err := m.migrate(&obj); err != nil {
+			return "", errors.New("failed to migrate config file from " + obj.APIVersion + " to " + m.to + ": " + err.Error())
+		}
+		obj.APIVersion = m.to
+	}
+
+	// The tokens are in the credential store now, the backup doesn't keep them
+	backup, err := redactTokens(data)
+	if err != nil {
+		return "", err
+	}
+	err = writePrivateFile(filename+"."+from+".bak", backup)
+	if err != nil {
+		return "", err
+	}
+
+	return from, writeObjToFile(obj, filename)
+}
+
+// redactTokens returns the config file data with the tokens of its users
+// replaced by RedactedValue, keeping the rest of the file as it was
+func redactTokens(data []byte) ([]byte, error) {
+	var obj yaml.MapSlice
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	for _, accounts := range mapValues(obj, "accounts") {
+		for _, account := range accounts {
+			accountObj, _ := account.(yaml.MapSlice)
+			for _, users := range mapValues(accountObj, "users") {
+				for _, user := range users {
+					userObj, _ := user.(yaml.MapSlice)
+					for i := range userObj {
+						if userObj[i].Key == "token" && userObj[i].Value != "" {
+							userObj[i].Value = RedactedValue
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return yaml.Marshal(obj)
+}
+
+// mapValues returns the lists found under key in obj
+func mapValues(obj yaml.MapSlice, key string) [][]interface{} {
+	var values [][]interface{}
+	for _, item := range obj {
+		if list, ok := item.Value.([]interface{}); ok && item.Key == key {
+			values = append(values, list)
+		}
+	}
+	return values
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	v1 := `apiVersion: v1
+kind: Config
+current-account: https://litmus.example.com
+current-user: admin
+accounts:
+  - endpoint: https://litmus.example.com
+    serverEndpoint: https://litmus.example.com
+    users:
+      - username: admin
+        token: admin-token
+        expires_in: "1735689600"
+`
+
+	tests := []struct {
+		name       string
+		content    string
+		wantFrom   string
+		wantBackup bool
+		wantErr    bool
+	}{
+		{
+			name:       "v1 config",
+			content:    v1,
+			wantFrom:   "v1",
+			wantBackup: true,
+		},
+		{
+			name:    "current config",
+			content: "apiVersion: " + CurrentAPIVersion + "\nkind: Config\naccounts: []\n",
+		},
+		{
+			name:    "empty config",
+			content: "",
+		},
+		{
+			name:    "unsupported version",
+			content: "apiVersion: v99\nkind: Config\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			from, err := Migrate(configPath)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error for unsupported version")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			if from != tt.wantFrom {
+				t.Errorf("Migrate() = %q, want %q", from, tt.wantFrom)
+			}
+
+			backup, err := os.ReadFile(configPath + "." + tt.wantFrom + ".bak")
+			if tt.wantBackup && (err != nil || !strings.Contains(string(backup), "apiVersion: "+tt.wantFrom)) {
+				t.Errorf("Backup not kept: got %q, %v", backup, err)
+			}
+			if tt.wantBackup && (strings.Contains(string(backup), "admin-token") || !strings.Contains(string(backup), "token: "+RedactedValue)) {
+				t.Errorf("Backup keeps the raw tokens: %s", backup)
+			}
+			if tt.wantBackup {
+				assertPrivateFile(t, configPath+"."+tt.wantFrom+".bak")
+			}
+			if !tt.wantBackup && err == nil {
+				t.Error("Backup written for a config that needs no migration")
+			}
+			if !tt.wantBackup {
+				return
+			}
+
+			cfg, err := YamltoObject(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read migrated config: %v", err)
+			}
+			if cfg.APIVersion != CurrentAPIVersion {
+				t.Errorf("APIVersion = %q, want %q", cfg.APIVersion, CurrentAPIVersion)
+			}
+			user, ok := FindUser(cfg, "admin", "https://litmus.example.com")
+			if !ok || user.Token != "admin-token" || user.TokenRef == "" {
+				t.Errorf("Token not moved to the credential store: got %+v", user)
+			}
+			if err := ConfigSyntaxCheck(configPath); err != nil {
+				t.Errorf("ConfigSyntaxCheck() error = %v on migrated config", err)
+			}
+
+			from, err = Migrate(configPath)
+			if err != nil || from != "" {
+				t.Errorf("Migrate() = %q, %v on migrated config, want no migration", from, err)
+			}
+		})
+	}
+}
+
+func TestMigratePlaintextTokens(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+	legacy := `accounts:
+- users:
+  - expires_in: "1735689600"
+    token: legacy-token
+    username: testuser
+  endpoint: https://test.example.com
+  serverEndpoint: https://test.example.com
+apiVersion: v2
+current-account: https://test.example.com
+current-user: testuser
+kind: Config
+`
+	if err := os.WriteFile(configPath, []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write legacy config: %v", err)
+	}
+
+	// The tokens are moved out of a current config as well, without a backup
+	from, err := Migrate(configPath)
+	if err != nil || from != "" {
+		t.Fatalf("Migrate() = %q, %v, want no version migration", from, err)
+	}
+	if _, err := os.Stat(configPath + ".v2.bak"); err == nil {
+		t.Error("Backup written for a current config")
+	}
+
+	raw, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	if strings.Contains(string(raw), "legacy-token") {
+		t.Errorf("Migrated config still holds the raw token:\n%s", raw)
+	}
+	assertPrivateFile(t, configPath)
+
+	cfg, err := YamltoObject(configPath)
+	if err != nil {
+		t.Fatalf("YamltoObject() error = %v", err)
+	}
+	if HasPlaintextTokens(cfg) {
+		t.Error("HasPlaintextTokens() = true after migration")
+	}
+	if got := cfg.Accounts[0].Users[0].Token; got != "legacy-token" {
+		t.Errorf("Loaded token = %q, want %q", got, "legacy-token")
+	}
+}
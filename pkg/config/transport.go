@@ -0,0 +1,170 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+)
+
+// transportSettings are the settings a transport is built from
+type transportSettings struct {
+	skipSSLVerify bool
+	caCert        string
+	clientCert    string
+	clientKey     string
+	proxy         string
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[transportSettings]*http.Transport{}
+)
+
+// Transport returns the transport used to reach the portal, configured with
+// SkipSSLVerify, CACert, ClientCert, ClientKey and Proxy. Transports are
+// shared between requests with the same settings, so that connections are reused.
+func Transport() (*http.Transport, error) {
+	return cachedTransport(transportSettings{
+		skipSSLVerify: SkipSSLVerify,
+		caCert:        CACert,
+		clientCert:    ClientCert,
+		clientKey:     ClientKey,
+		proxy:         Proxy,
+	})
+}
+
+// AccountTransport returns the transport used to reach the account, configured
+// with the TLS and proxy settings stored for it. SkipSSLVerify and CACert take
+// precedence over the stored settings when set.
+func AccountTransport(account types.Account) (*http.Transport, error) {
+	settings := transportSettings{
+		skipSSLVerify: SkipSSLVerify || account.InsecureSkipVerify,
+		caCert:        account.CACert,
+		clientCert:    account.ClientCert,
+		clientKey:     account.ClientKey,
+		proxy:         account.Proxy,
+	}
+	if CACert != "" {
+		settings.caCert = CACert
+	}
+
+	return cachedTransport(settings)
+}
+
+// RemoteFileTransport returns the transport used to read remote files, such
+// as manifests, configured with SkipSSLVerify and CACert only. The client
+// certificate and proxy of the portal are left out, as the files are
+// usually hosted elsewhere.
+func RemoteFileTransport() (*http.Transport, error) {
+	return cachedTransport(transportSettings{
+		skipSSLVerify: SkipSSLVerify,
+		caCert:        CACert,
+	})
+}
+
+func cachedTransport(settings transportSettings) (*http.Transport, error) {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	if transport, ok := transports[settings]; ok {
+		return transport, nil
+	}
+
+	transport, err := newTransport(settings)
+	if err != nil {
+		return nil, err
+	}
+	transports[settings] = transport
+	return transport, nil
+}
+
+func newTransport(settings transportSettings) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{InsecureSkipVerify: settings.skipSSLVerify}
+
+	if settings.caCert != "" && !settings.skipSSLVerify {
+		caCert, err := readPEM(settings.caCert)
+		if err != nil {
+			return nil, errors.New("failed to read the CA certificate: " + err.Error())
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no certificate found in the CA certificate " + describePEM(settings.caCert))
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.clientCert != "" || settings.clientKey != "" {
+		if settings.clientCert == "" || settings.clientKey == "" {
+			return nil, errors.New("mutual TLS needs both a client certificate and a client key")
+		}
+		certPEM, err := readPEM(settings.clientCert)
+		if err != nil {
+			return nil, errors.New("failed to read the client certificate: " + err.Error())
+		}
+		keyPEM, err := readPEM(settings.clientKey)
+		if err != nil {
+			return nil, errors.New("failed to read the client key: " + err.Error())
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, errors.New("invalid client certificate or key: " + err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if settings.proxy != "" {
+		proxyURL, err := url.Parse(settings.proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, errors.New("invalid proxy URL " + settings.proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// readPEM returns the value itself when it is inline PEM, or else reads the
+// file at that path
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return os.ReadFile(value)
+}
+
+func describePEM(value string) string {
+	if strings.Contains(value, "-----BEGIN") {
+		return "given inline"
+	}
+
+	return value
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// selfSignedPEM returns a self-signed client certificate and its key as PEM
+func selfSignedPEM(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "litmusctl"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestTransport(t *testing.T) {
+	clientCert, clientKey := selfSignedPEM(t)
+	clientPool := x509.NewCertPool()
+	clientPool.AppendCertsFromPEM([]byte(clientCert))
+
+	// The server only accepts requests authenticated with the client certificate
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientPool}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	if err := os.WriteFile(caFile, []byte(serverCA), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, []byte(clientKey), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		skipSSL       bool
+		caCert        string
+		clientCert    string
+		clientKey     string
+		proxy         string
+		wantErr       bool
+		wantReqFailed bool
+	}{
+		{
+			name:          "unknown server certificate",
+			clientCert:    clientCert,
+			clientKey:     keyFile,
+			wantReqFailed: true,
+		},
+		{
+			name:          "no client certificate",
+			caCert:        serverCA,
+			wantReqFailed: true,
+		},
+		{
+			name:       "mutual TLS with inline PEM and files",
+			caCert:     caFile,
+			clientCert: clientCert,
+			clientKey:  keyFile,
+		},
+		{
+			name:       "mutual TLS skipping verification",
+			skipSSL:    true,
+			clientCert: clientCert,
+			clientKey:  clientKey,
+		},
+		{
+			name:       "client certificate without key",
+			caCert:     caFile,
+			clientCert: clientCert,
+			wantErr:    true,
+		},
+		{
+			name:    "CA without certificates",
+			caCert:  "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed proxy",
+			proxy:   "not a url",
+			wantErr: true,
+		},
+	}
+
+	defer func() {
+		SkipSSLVerify, CACert, ClientCert, ClientKey, Proxy = false, "", "", "", ""
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SkipSSLVerify, CACert, ClientCert, ClientKey, Proxy = tt.skipSSL, tt.caCert, tt.clientCert, tt.clientKey, tt.proxy
+
+			transport, err := Transport()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Transport() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Transport() unexpected error = %v", err)
+			}
+
+			again, err := Transport()
+			if err != nil || again != transport {
+				t.Errorf("Transport() built a new transport for the same settings")
+			}
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if tt.wantReqFailed {
+				if err == nil {
+					resp.Body.Close()
+					t.Error("Request expected to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+		})
+	}
+
+	t.Run("proxy", func(t *testing.T) {
+		SkipSSLVerify, CACert, ClientCert, ClientKey, Proxy = false, "", "", "", "http://proxy.example.com:3128"
+
+		transport, err := Transport()
+		if err != nil {
+			t.Fatalf("Transport() unexpected error = %v", err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, "https://litmus.example.com", nil)
+		proxyURL, err := transport.Proxy(req)
+		if err != nil || proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+			t.Errorf("Proxy = %v, %v, want proxy.example.com:3128", proxyURL, err)
+		}
+	})
+}
@@ -0,0 +1,210 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is an issue found in a config file by Validate
+type Problem struct {
+	Line    int
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// Validate checks the config file for unknown keys, unsupported versions,
+// malformed endpoints, duplicate accounts, users and contexts, and current
+// account, user or context that don't exist. The problems are sorted by line.
+func Validate(filename string) ([]Problem, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.New("File format not correct " + err.Error())
+	}
+	if len(doc.Content) == 0 {
+		return []Problem{{Line: 1, Message: "config file is empty"}}, nil
+	}
+	root := doc.Content[0]
+
+	v := validator{}
+	v.unknownKeys(root, reflect.TypeOf(types.LitmuCtlConfig{}), "")
+
+	var obj types.LitmuCtlConfig
+	if err := root.Decode(&obj); err != nil {
+		return nil, errors.New("File format not correct " + err.Error())
+	}
+
+	if !IsKnownAPIVersion(obj.APIVersion) {
+		v.add(valueNode(root, "apiVersion", root), "unsupported apiVersion %q, supported versions are v1 to %s", obj.APIVersion, CurrentAPIVersion)
+	}
+	if obj.Kind != "Config" {
+		v.add(valueNode(root, "kind", root), "kind is %q, want \"Config\"", obj.Kind)
+	}
+
+	accountsNode := valueNode(root, "accounts", root)
+	endpoints := map[string]int{}
+	for i, account := range obj.Accounts {
+		accountNode := itemNode(accountsNode, i)
+		endpointNode := valueNode(accountNode, "endpoint", accountNode)
+		if line, ok := endpoints[account.Endpoint]; ok {
+			v.add(endpointNode, "duplicate account %s, first defined on line %d", account.Endpoint, line)
+		} else {
+			endpoints[account.Endpoint] = endpointNode.Line
+		}
+		v.endpoint(endpointNode, "endpoint", account.Endpoint)
+		if account.ServerEndpoint != "" {
+			v.endpoint(valueNode(accountNode, "serverEndpoint", accountNode), "serverEndpoint", account.ServerEndpoint)
+		}
+
+		usersNode := valueNode(accountNode, "users", accountNode)
+		usernames := map[string]int{}
+		for j, user := range account.Users {
+			usernameNode := valueNode(itemNode(usersNode, j), "username", usersNode)
+			if line, ok := usernames[user.Username]; ok {
+				v.add(usernameNode, "duplicate user %s of account %s, first defined on line %d", user.Username, account.Endpoint, line)
+			} else {
+				usernames[user.Username] = usernameNode.Line
+			}
+		}
+	}
+
+	contextsNode := valueNode(root, "contexts", root)
+	names := map[string]int{}
+	for i, context := range obj.Contexts {
+		contextNode := itemNode(contextsNode, i)
+		nameNode := valueNode(contextNode, "name", contextNode)
+		if line, ok := names[context.Name]; ok {
+			v.add(nameNode, "duplicate context %s, first defined on line %d", context.Name, line)
+		} else {
+			names[context.Name] = nameNode.Line
+		}
+		if !IsAccountExists(obj, context.Username, context.Endpoint) {
+			v.add(contextNode, "context %s refers to user %s of account %s, which doesn't exist", context.Name, context.Username, context.Endpoint)
+		}
+	}
+
+	if obj.CurrentAccount != "" {
+		if _, ok := endpoints[obj.CurrentAccount]; !ok {
+			v.add(valueNode(root, "current-account", root), "current-account %s doesn't exist", obj.CurrentAccount)
+		} else if !IsAccountExists(obj, obj.CurrentUser, obj.CurrentAccount) {
+			v.add(valueNode(root, "current-user", root), "current-user %s doesn't exist for account %s", obj.CurrentUser, obj.CurrentAccount)
+		}
+	}
+	if obj.CurrentContext != "" {
+		if _, ok := names[obj.CurrentContext]; !ok {
+			v.add(valueNode(root, "current-context", root), "current-context %s doesn't exist", obj.CurrentContext)
+		}
+	}
+
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].Line < v.problems[j].Line
+	})
+	return v.problems, nil
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(node *yaml.Node, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{Line: node.Line, Message: fmt.Sprintf(format, args...)})
+}
+
+// endpoint checks that the endpoint is an absolute http or https URL
+func (v *validator) endpoint(node *yaml.Node, key string, endpoint string) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.add(node, "malformed %s %q, want an http or https URL", key, endpoint)
+	}
+}
+
+// unknownKeys reports the keys of node that have no field in t, following the
+// yaml tags of the config types
+func (v *validator) unknownKeys(node *yaml.Node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := yamlField(t, key.Value)
+			if !ok {
+				v.add(key, "unknown key %q", path+key.Value)
+				continue
+			}
+			v.unknownKeys(value, field.Type, path+key.Value+".")
+		}
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			v.unknownKeys(item, t.Elem(), fmt.Sprintf("%s[%d].", strings.TrimSuffix(path, "."), i))
+		}
+	}
+}
+
+// yamlField returns the field of t with the given yaml key
+func yamlField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == key {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// valueNode returns the value of key in the mapping node, or fallback when it
+// has none, so that problems still point to the closest line
+func valueNode(node *yaml.Node, key string, fallback *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1]
+			}
+		}
+	}
+
+	return fallback
+}
+
+// itemNode returns the i-th item of the sequence node, or the node itself
+func itemNode(node *yaml.Node, i int) *yaml.Node {
+	if node.Kind == yaml.SequenceNode && i < len(node.Content) {
+		return node.Content[i]
+	}
+
+	return node
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Problem
+	}{
+		{
+			name: "valid config",
+			content: `apiVersion: v2
+kind: Config
+current-account: https://litmus.example.com
+current-user: admin
+current-context: prod
+accounts:
+  - endpoint: https://litmus.example.com
+    serverEndpoint: https://litmus.example.com
+    retries: 5
+    users:
+      - username: admin
+        tokenRef: file:admin@https://litmus.example.com
+        expires_in: "1735689600"
+        credentialSource:
+          passwordEnv: LITMUS_PASSWORD
+contexts:
+  - name: prod
+    endpoint: https://litmus.example.com
+    username: admin
+    projectID: project-1
+`,
+		},
+		{
+			name: "unknown keys",
+			content: `apiVersion: v1
+kind: Config
+accounts:
+  - endpoint: https://litmus.example.com
+    users:
+      - username: admin
+        passwd: secret
+currentAccount: https://litmus.example.com
+`,
+			want: []Problem{
+				{Line: 7, Message: `unknown key "accounts[0].users[0].passwd"`},
+				{Line: 8, Message: `unknown key "currentAccount"`},
+			},
+		},
+		{
+			name: "duplicates and malformed endpoints",
+			content: `apiVersion: v2
+kind: Config
+accounts:
+  - endpoint: https://litmus.example.com
+    users:
+      - username: admin
+      - username: admin
+  - endpoint: https://litmus.example.com
+    serverEndpoint: litmus.example.com:9002
+    users:
+      - username: ci
+  - endpoint: ftp://litmus.example.com
+    users:
+      - username: ci
+contexts:
+  - name: prod
+    endpoint: https://litmus.example.com
+    username: admin
+  - name: prod
+    endpoint: https://litmus.example.com
+    username: admin
+`,
+			want: []Problem{
+				{Line: 7, Message: "duplicate user admin of account https://litmus.example.com, first defined on line 6"},
+				{Line: 8, Message: "duplicate account https://litmus.example.com, first defined on line 4"},
+				{Line: 9, Message: `malformed serverEndpoint "litmus.example.com:9002", want an http or https URL`},
+				{Line: 12, Message: `malformed endpoint "ftp://litmus.example.com", want an http or https URL`},
+				{Line: 19, Message: "duplicate context prod, first defined on line 16"},
+			},
+		},
+		{
+			name: "dangling current account, user and context",
+			content: `apiVersion: v2
+kind: Config
+current-account: https://litmus.example.com
+current-user: ghost
+current-context: staging
+accounts:
+  - endpoint: https://litmus.example.com
+    users:
+      - username: admin
+contexts:
+  - name: prod
+    endpoint: https://other.example.com
+    username: admin
+`,
+			want: []Problem{
+				{Line: 4, Message: "current-user ghost doesn't exist for account https://litmus.example.com"},
+				{Line: 5, Message: "current-context staging doesn't exist"},
+				{Line: 11, Message: "context prod refers to user admin of account https://other.example.com, which doesn't exist"},
+			},
+		},
+		{
+			name: "unsupported version and kind",
+			content: `apiVersion: v99
+kind: Settings
+current-account: https://missing.example.com
+`,
+			want: []Problem{
+				{Line: 1, Message: `unsupported apiVersion "v99", supported versions are v1 to v2`},
+				{Line: 2, Message: `kind is "Settings", want "Config"`},
+				{Line: 3, Message: "current-account https://missing.example.com doesn't exist"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			got, err := Validate(configPath)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("error on invalid yaml", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "test_config.yaml")
+		if err := os.WriteFile(configPath, []byte("accounts: [\n"), 0600); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		if _, err := Validate(configPath); err == nil {
+			t.Error("Expected error for invalid yaml")
+		}
+	})
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package infra_ops
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -102,7 +103,7 @@ repeat:
 }
 
 // GetInfraDetails take details of Chaos Infrastructure as input
-func GetInfraDetails(mode string, pid string, c types.Credentials, kubeconfig *string) (types.Infra, error) {
+func GetInfraDetails(ctx context.Context, mode string, pid string, c types.Credentials, kubeconfig *string) (types.Infra, error) {
 	var newInfra types.Infra
 	// Get Infra name as input
 	utils.White_B.Println("\nEnter the details of the Chaos Infrastructure")
@@ -117,7 +118,7 @@ INFRA_NAME:
 	}
 
 	// Check if Chaos Infra with the given name already exists
-	isInfraExist, _, infra := ValidateInfraNameExists(newInfra.InfraName, pid, c)
+	isInfraExist, _, infra := ValidateInfraNameExists(ctx, newInfra.InfraName, pid, c)
 
 	if isInfraExist {
 		PrintExistingInfra(infra)
@@ -138,7 +139,7 @@ ENVIRONMENT:
 	}
 
 	// Check if Chaos Environment with the given name exists
-	Env, err := environment.ListChaosEnvironments(pid, c)
+	Env, err := environment.ListChaosEnvironments(ctx, pid, c)
 	if err != nil {
 		return types.Infra{}, err
 	}
@@ -242,18 +243,18 @@ ENVIRONMENT:
 	}
 
 	// Get platform name as input
-	newInfra.PlatformName = GetPlatformName(kubeconfig)
+	newInfra.PlatformName = GetPlatformName(ctx, kubeconfig)
 	// Set Infra type
 	newInfra.InfraType = utils.InfraTypeKubernetes
 	// Set project id
 	newInfra.ProjectId = pid
 	// Get namespace
-	newInfra.Namespace, newInfra.NsExists = k8s.ValidNs(mode, utils.ChaosInfraLabel, kubeconfig)
+	newInfra.Namespace, newInfra.NsExists = k8s.ValidNs(ctx, mode, utils.ChaosInfraLabel, kubeconfig)
 
 	return newInfra, nil
 }
 
-func ValidateSAPermissions(namespace string, mode string, kubeconfig *string) {
+func ValidateSAPermissions(ctx context.Context, namespace string, mode string, kubeconfig *string) {
 	var (
 		pems      [2]bool
 		err       error
@@ -267,7 +268,7 @@ func ValidateSAPermissions(namespace string, mode string, kubeconfig *string) {
 	}
 
 	for i, resource := range resources {
-		pems[i], err = k8s.CheckSAPermissions(k8s.CheckSAPermissionsParams{Verb: "create", Resource: resource, Print: true, Namespace: namespace}, kubeconfig)
+		pems[i], err = k8s.CheckSAPermissions(ctx, k8s.CheckSAPermissionsParams{Verb: "create", Resource: resource, Print: true, Namespace: namespace}, kubeconfig)
 		if err != nil {
 			utils.PrintFormattedError("Permission check failed", err)
 		}
@@ -284,8 +285,8 @@ func ValidateSAPermissions(namespace string, mode string, kubeconfig *string) {
 }
 
 // ValidateInfraNameExists checks if an infrastructure already exists
-func ValidateInfraNameExists(infraName string, pid string, c types.Credentials) (bool, error, infrastructure.InfraData) {
-	infra, err := infrastructure.GetInfraList(c, pid, model.ListInfraRequest{})
+func ValidateInfraNameExists(ctx context.Context, infraName string, pid string, c types.Credentials) (bool, error, infrastructure.InfraData) {
+	infra, err := infrastructure.GetInfraList(ctx, c, pid, model.ListInfraRequest{})
 	if err != nil {
 		return false, err, infrastructure.InfraData{}
 	}
@@ -300,15 +301,15 @@ func ValidateInfraNameExists(infraName string, pid string, c types.Credentials)
 }
 
 // Summary display the Infra details based on input
-func Summary(infra types.Infra, kubeconfig *string) {
+func Summary(ctx context.Context, infra types.Infra, kubeconfig *string) {
 	utils.White_B.Printf("\n📌 Summary \nChaos Infra Name: %s\nChaos EnvironmentID: %s\nChaos Infra Description: %s\nChaos Infra SSL/TLS Skip: %t\nPlatform Name: %s\n", infra.InfraName, infra.EnvironmentID, infra.Description, infra.SkipSSL, infra.PlatformName)
-	if ok, _ := k8s.NsExists(infra.Namespace, kubeconfig); ok {
+	if ok, _ := k8s.NsExists(ctx, infra.Namespace, kubeconfig); ok {
 		utils.White_B.Println("Namespace: ", infra.Namespace)
 	} else {
 		utils.White_B.Println("Namespace: ", infra.Namespace, "(new)")
 	}
 
-	if k8s.SAExists(k8s.SAExistsParams{Namespace: infra.Namespace, Serviceaccount: infra.ServiceAccount}, kubeconfig) {
+	if k8s.SAExists(ctx, k8s.SAExistsParams{Namespace: infra.Namespace, Serviceaccount: infra.ServiceAccount}, kubeconfig) {
 		utils.White_B.Println("Service Account: ", infra.ServiceAccount)
 	} else {
 		utils.White_B.Println("Service Account: ", infra.ServiceAccount, "(new)")
@@ -330,13 +331,13 @@ func ConfirmInstallation() {
 	}
 }
 
-func CreateRandomProject(cred types.Credentials) string {
+func CreateRandomProject(ctx context.Context, cred types.Credentials) string {
 	rand, err := utils.GenerateRandomString(10)
 	utils.PrintError(err)
 
 	projectName := cred.Username + "-" + rand
 
-	project, err := apis.CreateProjectRequest(projectName, cred)
+	project, err := apis.CreateProjectRequest(ctx, projectName, cred)
 	utils.PrintError(err)
 
 	return project.Data.ID
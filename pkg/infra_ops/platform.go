@@ -28,9 +28,9 @@ import (
 // - Entering any character other than numbers returns 0. Input validation need to be done.
 // - If input is given as "123abc", "abc" will be used for next user input. Buffer need to be read completely.
 // - String literals like "AWS" are used at multiple places. Need to be changed to constants.
-func GetPlatformName(kubeconfig *string) string {
+func GetPlatformName(ctx context.Context, kubeconfig *string) string {
 	var platform int
-	discoveredPlatform := DiscoverPlatform(kubeconfig)
+	discoveredPlatform := DiscoverPlatform(ctx, kubeconfig)
 	utils.White_B.Println("\nPlatform List: ")
 	utils.White_B.Println(utils.PlatformList)
 	utils.White_B.Print("\nSelect a platform [Default: ", discoveredPlatform, "] [Range: 1-5]: ")
@@ -52,17 +52,17 @@ func GetPlatformName(kubeconfig *string) string {
 }
 
 // discoverPlatform determines the host platform and returns it
-func DiscoverPlatform(kubeconfig *string) string {
-	if ok, _ := IsAWSPlatform(kubeconfig); ok {
+func DiscoverPlatform(ctx context.Context, kubeconfig *string) string {
+	if ok, _ := IsAWSPlatform(ctx, kubeconfig); ok {
 		return "AWS"
 	}
-	if ok, _ := IsGKEPlatform(kubeconfig); ok {
+	if ok, _ := IsGKEPlatform(ctx, kubeconfig); ok {
 		return "GKE"
 	}
-	if ok, _ := IsOpenshiftPlatform(kubeconfig); ok {
+	if ok, _ := IsOpenshiftPlatform(ctx, kubeconfig); ok {
 		return "Openshift"
 	}
-	if ok, _ := k8s.NsExists("cattle-system", kubeconfig); ok {
+	if ok, _ := k8s.NsExists(ctx, "cattle-system", kubeconfig); ok {
 		return "Rancher"
 	}
 	return utils.DefaultPlatform
@@ -82,12 +82,12 @@ func DiscoverPlatform(kubeconfig *string) string {
 //	    }
 //	  }
 //	}
-func IsAWSPlatform(kubeconfig *string) (bool, error) {
+func IsAWSPlatform(ctx context.Context, kubeconfig *string) (bool, error) {
 	clientset, err := k8s.ClientSet(kubeconfig)
 	if err != nil {
 		return false, err
 	}
-	nodeList, err := clientset.CoreV1().Nodes().List(context.TODO(), v1.ListOptions{})
+	nodeList, err := clientset.CoreV1().Nodes().List(ctx, v1.ListOptions{})
 	if err != nil || len(nodeList.Items) == 0 {
 		return false, err
 	}
@@ -111,12 +111,12 @@ func IsAWSPlatform(kubeconfig *string) (bool, error) {
 //	    }
 //	  }
 //	}
-func IsGKEPlatform(kubeconfig *string) (bool, error) {
+func IsGKEPlatform(ctx context.Context, kubeconfig *string) (bool, error) {
 	clientset, err := k8s.ClientSet(kubeconfig)
 	if err != nil {
 		return false, err
 	}
-	nodeList, err := clientset.CoreV1().Nodes().List(context.TODO(), v1.ListOptions{})
+	nodeList, err := clientset.CoreV1().Nodes().List(ctx, v1.ListOptions{})
 	if err != nil || len(nodeList.Items) == 0 {
 		return false, err
 	}
@@ -142,12 +142,12 @@ func IsGKEPlatform(kubeconfig *string) (bool, error) {
 //	   }
 //	   ....
 //	}
-func IsOpenshiftPlatform(kubeconfig *string) (bool, error) {
+func IsOpenshiftPlatform(ctx context.Context, kubeconfig *string) (bool, error) {
 	clientset, err := k8s.ClientSet(kubeconfig)
 	if err != nil {
 		return false, err
 	}
-	nodeList, err := clientset.CoreV1().Nodes().List(context.TODO(), v1.ListOptions{
+	nodeList, err := clientset.CoreV1().Nodes().List(ctx, v1.ListOptions{
 		LabelSelector: utils.OpenshiftIdentifier,
 	})
 	if err != nil {
@@ -0,0 +1,51 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package k8s
+
+import (
+	"context"
+
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// ChaosEngineResource is the resource of the ChaosEngines of the chaos-operator
+var ChaosEngineResource = schema.GroupVersionResource{Group: "litmuschaos.io", Version: "v1alpha1", Resource: "chaosengines"}
+
+// DynamicClient returns a dynamic client for the kubeconfig, or for
+// $HOME/.kube/config when it is empty
+func DynamicClient(kubeconfig string) (dynamic.Interface, error) {
+	_, _, dynamicClient, err := getClientAndConfig(kubeconfig)
+	return dynamicClient, err
+}
+
+// ChaosEngineStatus returns the engineStatus of the ChaosEngine, and false
+// when the ChaosEngine doesn't exist
+func ChaosEngineStatus(ctx context.Context, client dynamic.Interface, namespace string, name string) (string, bool, error) {
+	engine, err := client.Resource(ChaosEngineResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if k8serror.IsNotFound(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	status, _, err := unstructured.NestedString(engine.Object, "status", "engineStatus")
+	return status, true, err
+}
@@ -20,10 +20,12 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/litmuschaos/litmusctl/pkg/config"
 	"github.com/litmuschaos/litmusctl/pkg/utils"
 
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
@@ -41,7 +43,7 @@ func ClientSet(kubeconfig *string) (*kubernetes.Clientset, error) {
 	}
 
 	// create the config
-	config, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	config, err := buildConfig(*kubeconfig)
 	if err != nil {
 		utils.PrintFormattedError("Failed to build kubernetes config", err)
 		os.Exit(1)
@@ -55,3 +57,12 @@ func ClientSet(kubeconfig *string) (*kubernetes.Clientset, error) {
 	}
 	return clientset, err
 }
+
+// buildConfig loads the kubeconfig file, using config.KubeContext instead of
+// its current context when set
+func buildConfig(kubeconfig string) (*rest.Config, error) {
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: config.KubeContext},
+	).ClientConfig()
+}
@@ -30,7 +30,6 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
-	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 
 	"github.com/litmuschaos/litmusctl/pkg/utils"
@@ -60,12 +59,12 @@ type CanIOptions struct {
 }
 
 // NsExists checks if the given namespace already exists
-func NsExists(namespace string, kubeconfig *string) (bool, error) {
+func NsExists(ctx context.Context, namespace string, kubeconfig *string) (bool, error) {
 	clientset, err := ClientSet(kubeconfig)
 	if err != nil {
 		return false, err
 	}
-	ns, err := clientset.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
 	if k8serror.IsNotFound(err) {
 		return false, nil
 	}
@@ -84,7 +83,7 @@ type CheckSAPermissionsParams struct {
 	Namespace string
 }
 
-func CheckSAPermissions(params CheckSAPermissionsParams, kubeconfig *string) (bool, error) {
+func CheckSAPermissions(ctx context.Context, params CheckSAPermissionsParams, kubeconfig *string) (bool, error) {
 
 	var o CanIOptions
 	o.Verb = params.Verb
@@ -110,7 +109,7 @@ func CheckSAPermissions(params CheckSAPermissionsParams, kubeconfig *string) (bo
 		},
 	}
 
-	response, err := AuthClient.SelfSubjectAccessReviews().Create(context.TODO(), sar, metav1.CreateOptions{})
+	response, err := AuthClient.SelfSubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
 	if err != nil {
 		return false, err
 	}
@@ -135,7 +134,7 @@ func CheckSAPermissions(params CheckSAPermissionsParams, kubeconfig *string) (bo
 }
 
 // ValidNs takes a valid namespace as input from user
-func ValidNs(mode string, label string, kubeconfig *string) (string, bool) {
+func ValidNs(ctx context.Context, mode string, label string, kubeconfig *string) (string, bool) {
 start:
 	var (
 		namespace string
@@ -157,13 +156,13 @@ start:
 	if namespace == "" {
 		namespace = utils.DefaultNs
 	}
-	ok, err := NsExists(namespace, kubeconfig)
+	ok, err := NsExists(ctx, namespace, kubeconfig)
 	if err != nil {
 		utils.Red.Printf("\n 🚫 Namespace existence check failed: {%s}\n", err.Error())
 		os.Exit(1)
 	}
 	if ok {
-		if podExists(podExistsParams{namespace, label}, kubeconfig) {
+		if podExists(ctx, podExistsParams{namespace, label}, kubeconfig) {
 			utils.Red.Println("\n🚫 There is a Chaos Infra already present in this namespace. Please enter a different namespace")
 			goto start
 		} else {
@@ -171,7 +170,7 @@ start:
 			utils.White_B.Println("👍 Continuing with", namespace, "namespace")
 		}
 	} else {
-		if val, _ := CheckSAPermissions(CheckSAPermissionsParams{"create", "namespace", false, namespace}, kubeconfig); !val {
+		if val, _ := CheckSAPermissions(ctx, CheckSAPermissionsParams{"create", "namespace", false, namespace}, kubeconfig); !val {
 			utils.Red.Println("🚫 You don't have permissions to create a namespace.\n Please enter an existing namespace.")
 			goto start
 		}
@@ -187,12 +186,12 @@ type WatchPodParams struct {
 }
 
 // WatchPod watches for the pod status
-func WatchPod(params WatchPodParams, kubeconfig *string) {
+func WatchPod(ctx context.Context, params WatchPodParams, kubeconfig *string) {
 	clientset, err := ClientSet(kubeconfig)
 	if err != nil {
 		log.Fatal(err)
 	}
-	watch, err := clientset.CoreV1().Pods(params.Namespace).Watch(context.TODO(), metav1.ListOptions{
+	watch, err := clientset.CoreV1().Pods(params.Namespace).Watch(ctx, metav1.ListOptions{
 		LabelSelector: params.Label,
 	})
 	if err != nil {
@@ -222,13 +221,13 @@ type podExistsParams struct {
 }
 
 // PodExists checks if the pod with the given label already exists in the given namespace
-func podExists(params podExistsParams, kubeconfig *string) bool {
+func podExists(ctx context.Context, params podExistsParams, kubeconfig *string) bool {
 	clientset, err := ClientSet(kubeconfig)
 	if err != nil {
 		log.Fatal(err)
 		return false
 	}
-	watch, err := clientset.CoreV1().Pods(params.Namespace).List(context.TODO(), metav1.ListOptions{
+	watch, err := clientset.CoreV1().Pods(params.Namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: params.Label,
 	})
 	if err != nil {
@@ -248,13 +247,13 @@ type SAExistsParams struct {
 }
 
 // SAExists checks if the given service account exists in the given namespace
-func SAExists(params SAExistsParams, kubeconfig *string) bool {
+func SAExists(ctx context.Context, params SAExistsParams, kubeconfig *string) bool {
 	clientset, err := ClientSet(kubeconfig)
 	if err != nil {
 		log.Fatal(err)
 	}
 	msg := fmt.Sprintf("serviceaccounts \"%s\" not found", params.Serviceaccount)
-	_, newErr := clientset.CoreV1().ServiceAccounts(params.Namespace).Get(context.TODO(), params.Serviceaccount, metav1.GetOptions{})
+	_, newErr := clientset.CoreV1().ServiceAccounts(params.Namespace).Get(ctx, params.Serviceaccount, metav1.GetOptions{})
 	if newErr != nil {
 		if newErr.Error() == msg {
 			return false
@@ -265,14 +264,14 @@ func SAExists(params SAExistsParams, kubeconfig *string) bool {
 }
 
 // ValidSA gets a valid service account as input
-func ValidSA(namespace string, kubeconfig *string) (string, bool) {
+func ValidSA(ctx context.Context, namespace string, kubeconfig *string) (string, bool) {
 	var sa string
 	utils.White_B.Print("\nEnter service account [Default: ", utils.DefaultSA, "]: ")
 	fmt.Scanln(&sa)
 	if sa == "" {
 		sa = utils.DefaultSA
 	}
-	if SAExists(SAExistsParams{namespace, sa}, kubeconfig) {
+	if SAExists(ctx, SAExistsParams{namespace, sa}, kubeconfig) {
 		utils.White_B.Print("\n👍 Using the existing service account")
 		return sa, true
 	}
@@ -280,7 +279,7 @@ func ValidSA(namespace string, kubeconfig *string) (string, bool) {
 }
 
 // ApplyManifest applies the provided manifest and kubeconfig with the help of client-go library.
-func ApplyManifest(manifest []byte, kubeconfig string) (string, error) {
+func ApplyManifest(ctx context.Context, manifest []byte, kubeconfig string) (string, error) {
 
 	// Get Kubernetes and dynamic clients along with the configuration.
 	_, kubeClient, dynamicClient, err := getClientAndConfig(kubeconfig)
@@ -295,7 +294,7 @@ func ApplyManifest(manifest []byte, kubeconfig string) (string, error) {
 	}
 
 	// Apply the decoded resources using the dynamic client and Kubernetes client.
-	err = applyResources(resources, dynamicClient, kubeClient)
+	err = applyResources(ctx, resources, dynamicClient, kubeClient)
 	if err != nil {
 		return "", err
 	}
@@ -311,7 +310,7 @@ func getClientAndConfig(kubeconfig string) (*rest.Config, *kubernetes.Clientset,
 	// If kubeconfig is provided, use it to create the configuration and dynamic client.
 	if kubeconfig != "" {
 		var err error
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		config, err = buildConfig(kubeconfig)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to build config from flags: %w", err)
 		}
@@ -321,7 +320,7 @@ func getClientAndConfig(kubeconfig string) (*rest.Config, *kubernetes.Clientset,
 		defaultKubeconfig := filepath.Join(home, ".kube", "config")
 		if _, err := os.Stat(defaultKubeconfig); !os.IsNotExist(err) {
 			var err error
-			config, err = clientcmd.BuildConfigFromFlags("", defaultKubeconfig)
+			config, err = buildConfig(defaultKubeconfig)
 			if err != nil {
 				return nil, nil, nil, fmt.Errorf("failed to build config from flags: %w", err)
 			}
@@ -367,7 +366,7 @@ func decodeManifest(manifest []byte) ([]*unstructured.Unstructured, error) {
 }
 
 // applies the decoded resources using the dynamic client and Kubernetes client.
-func applyResources(resources []*unstructured.Unstructured, dynamicClient dynamic.Interface, kubeClient *kubernetes.Clientset) error {
+func applyResources(ctx context.Context, resources []*unstructured.Unstructured, dynamicClient dynamic.Interface, kubeClient *kubernetes.Clientset) error {
 	for _, resource := range resources {
 		logrus.Infof("Applying resource: %s , kind: %s", resource.GetName(), resource.GetKind())
 
@@ -390,7 +389,7 @@ func applyResources(resources []*unstructured.Unstructured, dynamicClient dynami
 		}
 
 		// Apply the resource using the dynamic client.
-		_, err = dr.Apply(context.TODO(), resource.GetName(), resource, metav1.ApplyOptions{
+		_, err = dr.Apply(ctx, resource.GetName(), resource, metav1.ApplyOptions{
 			Force:        true,
 			FieldManager: "application/apply-patch",
 		})
@@ -0,0 +1,62 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package k8s
+
+import (
+	"context"
+	"io"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ListPods returns the pods of the namespace matching the label selector
+func ListPods(ctx context.Context, clientset kubernetes.Interface, namespace string, label string) ([]v1.Pod, error) {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: label,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pods.Items, nil
+}
+
+type PodLogsParams struct {
+	Namespace string
+	Pod       string
+	Container string
+	Follow    bool
+	// Since only returns the logs newer than this duration when set
+	Since time.Duration
+}
+
+// StreamPodLogs returns the logs of a container of the pod. The stream has
+// to be closed by the caller.
+func StreamPodLogs(ctx context.Context, clientset kubernetes.Interface, params PodLogsParams) (io.ReadCloser, error) {
+	options := &v1.PodLogOptions{
+		Container: params.Container,
+		Follow:    params.Follow,
+	}
+	if params.Since > 0 {
+		seconds := int64(params.Since.Seconds())
+		options.SinceSeconds = &seconds
+	}
+
+	return clientset.CoreV1().Pods(params.Namespace).GetLogs(params.Pod, options).Stream(ctx)
+}
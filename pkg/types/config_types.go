@@ -16,15 +16,34 @@ limitations under the License.
 package types
 
 type User struct {
-	ExpiresIn string `yaml:"expires_in" json:"expires_in"`
-	Token     string `yaml:"token" json:"token"`
-	Username  string `yaml:"username" json:"username"`
+	ExpiresIn        string            `yaml:"expires_in" json:"expires_in"`
+	Token            string            `yaml:"token,omitempty" json:"token,omitempty"`
+	TokenRef         string            `yaml:"tokenRef,omitempty" json:"tokenRef,omitempty"`
+	Username         string            `yaml:"username" json:"username"`
+	CredentialSource *CredentialSource `yaml:"credentialSource,omitempty" json:"credentialSource,omitempty"`
+}
+
+// CredentialSource tells litmusctl where to find the secret used to renew an
+// expired token without prompting the user
+type CredentialSource struct {
+	PasswordEnv  string `yaml:"passwordEnv,omitempty" json:"passwordEnv,omitempty"`
+	PasswordFile string `yaml:"passwordFile,omitempty" json:"passwordFile,omitempty"`
+	APITokenFile string `yaml:"apiTokenFile,omitempty" json:"apiTokenFile,omitempty"`
 }
 
 type Account struct {
 	Users          []User `yaml:"users" json:"users"`
 	Endpoint       string `yaml:"endpoint" json:"endpoint"`
 	ServerEndpoint string `yaml:"serverEndpoint" json:"serverEndpoint"`
+	Retries        *int   `yaml:"retries,omitempty" json:"retries,omitempty"`
+	RetryMaxWait   string `yaml:"retryMaxWait,omitempty" json:"retryMaxWait,omitempty"`
+	// TLS and proxy settings of the connection to ChaosCenter. Certificates
+	// and keys are file paths or inline PEM.
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify,omitempty" json:"insecureSkipVerify,omitempty"`
+	CACert             string `yaml:"caCert,omitempty" json:"caCert,omitempty"`
+	ClientCert         string `yaml:"clientCert,omitempty" json:"clientCert,omitempty"`
+	ClientKey          string `yaml:"clientKey,omitempty" json:"clientKey,omitempty"`
+	Proxy              string `yaml:"proxy,omitempty" json:"proxy,omitempty"`
 }
 
 type LitmuCtlConfig struct {
@@ -33,6 +52,22 @@ type LitmuCtlConfig struct {
 	CurrentAccount string    `yaml:"current-account" json:"current-account"`
 	CurrentUser    string    `yaml:"current-user" json:"current-user"`
 	Kind           string    `yaml:"kind" json:"kind"`
+	// CredentialStore is where new tokens are kept, "file" when empty
+	CredentialStore string    `yaml:"credentialStore,omitempty" json:"credentialStore,omitempty"`
+	Contexts        []Context `yaml:"contexts,omitempty" json:"contexts,omitempty"`
+	CurrentContext  string    `yaml:"current-context,omitempty" json:"current-context,omitempty"`
+}
+
+// Context names an account and user along with the defaults commands use
+// instead of prompting for them
+type Context struct {
+	Name        string `yaml:"name" json:"name"`
+	Endpoint    string `yaml:"endpoint" json:"endpoint"`
+	Username    string `yaml:"username" json:"username"`
+	ProjectID   string `yaml:"projectID,omitempty" json:"projectID,omitempty"`
+	InfraID     string `yaml:"infraID,omitempty" json:"infraID,omitempty"`
+	Kubeconfig  string `yaml:"kubeconfig,omitempty" json:"kubeconfig,omitempty"`
+	KubeContext string `yaml:"kubeContext,omitempty" json:"kubeContext,omitempty"`
 }
 
 type Current struct {
@@ -45,4 +80,6 @@ type UpdateLitmusCtlConfig struct {
 	CurrentUser    string  `yaml:"current-user" json:"current-user"`
 	Account        Account `yaml:"account" json:"account"`
 	ServerEndpoint string  `yaml:"serverEndpoint" json:"serverEndpoint"`
+	// CredentialStore replaces the credential store of the config when set
+	CredentialStore string `yaml:"credentialStore,omitempty" json:"credentialStore,omitempty"`
 }
@@ -0,0 +1,55 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+// ExecutionData is the state of an experiment run reported by the Chaos
+// Infrastructure, as found in the executionData of the run
+type ExecutionData struct {
+	ExperimentType string                   `json:"experimentType"`
+	ExperimentID   string                   `json:"experimentID"`
+	Namespace      string                   `json:"namespace"`
+	Name           string                   `json:"name"`
+	Phase          string                   `json:"phase"`
+	Message        string                   `json:"message"`
+	StartedAt      string                   `json:"startedAt"`
+	FinishedAt     string                   `json:"finishedAt"`
+	Nodes          map[string]ExecutionNode `json:"nodes"`
+}
+
+// ExecutionNode is a step of the workflow of an experiment run
+type ExecutionNode struct {
+	Name       string     `json:"name"`
+	Phase      string     `json:"phase"`
+	Message    string     `json:"message"`
+	StartedAt  string     `json:"startedAt"`
+	FinishedAt string     `json:"finishedAt"`
+	Children   []string   `json:"children"`
+	Type       string     `json:"type"`
+	ChaosData  *ChaosData `json:"chaosData,omitempty"`
+}
+
+// ChaosData is the state of the fault injected by a step
+type ChaosData struct {
+	EngineUID         string `json:"engineUID"`
+	EngineName        string `json:"engineName"`
+	Namespace         string `json:"namespace"`
+	ExperimentName    string `json:"experimentName"`
+	ExperimentStatus  string `json:"experimentStatus"`
+	ExperimentVerdict string `json:"experimentVerdict"`
+	ExperimentPod     string `json:"experimentPod"`
+	RunnerPod         string `json:"runnerPod"`
+	FailStep          string `json:"failStep"`
+}
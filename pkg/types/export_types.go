@@ -0,0 +1,82 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+const (
+	// ProjectExportIndex is the file name of the index of an exported project
+	ProjectExportIndex      = "index.yaml"
+	ProjectExportAPIVersion = "v1"
+	ProjectExportKind       = "ProjectExport"
+)
+
+// ProjectExport is the index of a project exported by litmusctl. Each
+// resource is kept in its own file, relative to the directory of the index.
+type ProjectExport struct {
+	APIVersion      string               `json:"apiVersion"`
+	Kind            string               `json:"kind"`
+	ProjectID       string               `json:"projectID"`
+	Endpoint        string               `json:"endpoint"`
+	ExportedAt      string               `json:"exportedAt"`
+	Environments    []ExportedResource   `json:"environments"`
+	Infrastructures []ExportedInfra      `json:"infrastructures"`
+	Probes          []ExportedProbe      `json:"probes"`
+	Experiments     []ExportedExperiment `json:"experiments"`
+}
+
+type ExportedResource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+type ExportedInfra struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	EnvironmentID string `json:"environmentID"`
+	File          string `json:"file"`
+}
+
+type ExportedProbe struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	File string `json:"file"`
+	// Manifest is the probe as referenced from a ChaosEngine
+	Manifest string `json:"manifest,omitempty"`
+}
+
+type ExportedExperiment struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	InfraID     string   `json:"infraID"`
+	CronSyntax  string   `json:"cronSyntax,omitempty"`
+	File        string   `json:"file"`
+}
+
+// InfraMetadata describes an exported Chaos Infrastructure. Infrastructures
+// can't be imported, they are only matched with those of the target project.
+type InfraMetadata struct {
+	InfraID        string   `json:"infraID"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	EnvironmentID  string   `json:"environmentID"`
+	PlatformName   string   `json:"platformName,omitempty"`
+	InfraNamespace string   `json:"infraNamespace,omitempty"`
+	ServiceAccount string   `json:"serviceAccount,omitempty"`
+	InfraScope     string   `json:"infraScope,omitempty"`
+}
@@ -18,6 +18,7 @@ package utils
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/json"
 	"errors"
@@ -25,9 +26,12 @@ import (
 	"math/big"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/golang-jwt/jwt"
 	"github.com/litmuschaos/litmusctl/pkg/config"
 	"github.com/litmuschaos/litmusctl/pkg/types"
 	"github.com/mitchellh/go-homedir"
@@ -35,10 +39,19 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// tokenRefreshWindow is how long before its expiry a token gets renewed, so
+// that it doesn't expire in the middle of a command
+const tokenRefreshWindow = 5 * time.Minute
+
+// Reauthenticate renews the token of an expired user. It is set by the root
+// command to apis.Reauthenticate, as the apis package depends on this one.
+var Reauthenticate func(ctx context.Context, endpoint string, user types.User) (types.User, error)
+
 var (
 	Red     = color.New(color.FgRed)
 	White_B = color.New(color.FgWhite, color.Bold)
 	White   = color.New(color.FgWhite)
+	Green   = color.New(color.FgGreen)
 )
 
 func Scanner() string {
@@ -51,11 +64,30 @@ func Scanner() string {
 	}
 	return ""
 }
+
+// exitCoder is implemented by errors that carry their own process exit code
+type exitCoder interface {
+	ExitCode() int
+}
+
 func PrintError(err error) {
 	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			Red.Println("\n⛔ Operation cancelled")
+			os.Exit(130)
+		}
 		PrintFormattedError("Error", err)
-		os.Exit(1)
+		os.Exit(ExitCode(err))
+	}
+}
+
+// ExitCode returns the exit code carried by err, or 1 when it has none
+func ExitCode(err error) int {
+	var coder exitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
 	}
+	return 1
 }
 
 func GetLitmusConfigPath(cmd *cobra.Command) string {
@@ -72,37 +104,278 @@ func GetLitmusConfigPath(cmd *cobra.Command) string {
 	return configFilePath
 }
 
+// GetCredentials returns the credentials of the current user. The account of
+// a context passed with --context comes first. Then environment variables take
+// precedence over the config file: LITMUS_ENDPOINT and LITMUS_TOKEN together
+// replace it entirely, while LITMUS_ENDPOINT, LITMUS_USERNAME and
+// LITMUS_SERVER_ENDPOINT alone select the account, the user and the server
+// endpoint to use from it. Otherwise the account and user of the current
+// context are used, then the current ones.
 func GetCredentials(cmd *cobra.Command) (types.Credentials, error) {
 	configFilePath := GetLitmusConfigPath(cmd)
 
+	litmusContext, err := GetContext(cmd)
+	if err != nil {
+		return types.Credentials{}, err
+	}
+	config.KubeContext = litmusContext.KubeContext
+
+	// An account selected with --context wins over the environment
+	var endpoint, serverEndpointEnv, username string
+	contextFlag := cmd.Flags().Lookup("context")
+	if contextFlag == nil || contextFlag.Value.String() == "" || (litmusContext.Endpoint == "" && litmusContext.Username == "") {
+		endpoint = os.Getenv(EndpointEnv)
+		serverEndpointEnv = os.Getenv(ServerEndpointEnv)
+		username = os.Getenv(UsernameEnv)
+		if token := os.Getenv(TokenEnv); token != "" {
+			return envCredentials(endpoint, serverEndpointEnv, username, token)
+		}
+	}
+	fromEnv := endpoint != "" || username != ""
+
+	if !config.FileExists(configFilePath) {
+		return types.Credentials{}, errors.New("no config file found at " + configFilePath + ", run `litmusctl config set-account` or set " + EndpointEnv + " and " + TokenEnv)
+	}
+
 	obj, err := config.YamltoObject(configFilePath)
 	PrintError(err)
 
-	if obj.CurrentUser == "" || obj.CurrentAccount == "" {
+	if endpoint == "" && username == "" {
+		endpoint, username = litmusContext.Endpoint, litmusContext.Username
+	}
+	if endpoint == "" {
+		endpoint = obj.CurrentAccount
+	}
+	if username == "" {
+		username = obj.CurrentUser
+	}
+	if username == "" || endpoint == "" {
 		return types.Credentials{}, errors.New("Current user or current account is not set")
 	}
 
 	var token string
 	var serverEndpoint string
 	for _, account := range obj.Accounts {
-		if account.Endpoint == obj.CurrentAccount {
+		if account.Endpoint == endpoint {
 			serverEndpoint = account.ServerEndpoint
+			if err := ApplyAccountSettings(cmd, account); err != nil {
+				return types.Credentials{}, err
+			}
 			for _, user := range account.Users {
-				if user.Username == obj.CurrentUser {
+				if user.Username == username {
+					if TokenExpired(user) {
+						user, err = renewToken(cmd, configFilePath, account.Endpoint, user)
+						if err != nil {
+							return types.Credentials{}, err
+						}
+					}
 					token = user.Token
 				}
 			}
 		}
 	}
 
+	if token == "" && fromEnv {
+		return types.Credentials{}, errors.New("no token found for user " + username + " of account " + endpoint + ", set " + TokenEnv)
+	}
+	if serverEndpointEnv != "" {
+		serverEndpoint = serverEndpointEnv
+	}
+
 	return types.Credentials{
-		Username:       obj.CurrentUser,
+		Username:       username,
 		Token:          token,
-		Endpoint:       obj.CurrentAccount,
+		Endpoint:       endpoint,
 		ServerEndpoint: serverEndpoint,
 	}, nil
 }
 
+// envCredentials returns the credentials given by the environment variables
+func envCredentials(endpoint string, serverEndpoint string, username string, token string) (types.Credentials, error) {
+	if endpoint == "" {
+		return types.Credentials{}, errors.New(TokenEnv + " is set but " + EndpointEnv + " is not")
+	}
+	if serverEndpoint == "" {
+		serverEndpoint = endpoint
+	}
+
+	claims := jwt.MapClaims{}
+	// The token is verified by ChaosCenter, only its claims are needed here
+	_, _, err := new(jwt.Parser).ParseUnverified(token, claims)
+	if err != nil {
+		return types.Credentials{}, errors.New(TokenEnv + " is not a valid token: " + err.Error())
+	}
+	if exp, ok := claims["exp"].(float64); ok && !time.Unix(int64(exp), 0).After(time.Now()) {
+		return types.Credentials{}, errors.New("the token in " + TokenEnv + " has expired")
+	}
+	if username == "" {
+		username, _ = claims["username"].(string)
+	}
+
+	return types.Credentials{
+		Username:       username,
+		Token:          token,
+		Endpoint:       strings.TrimRight(endpoint, "/"),
+		ServerEndpoint: strings.TrimRight(serverEndpoint, "/"),
+	}, nil
+}
+
+// GetContext returns the context selected with --context, or else the current
+// context of the config file. It is empty when there is neither.
+func GetContext(cmd *cobra.Command) (types.Context, error) {
+	var name string
+	if flag := cmd.Flags().Lookup("context"); flag != nil {
+		name = flag.Value.String()
+	}
+
+	configFilePath := GetLitmusConfigPath(cmd)
+	if !config.FileExists(configFilePath) {
+		if name != "" {
+			return types.Context{}, errors.New("context " + name + " not found, there is no config file at " + configFilePath)
+		}
+		return types.Context{}, nil
+	}
+
+	obj, err := config.YamltoObject(configFilePath)
+	if err != nil {
+		return types.Context{}, err
+	}
+	if name == "" {
+		name = obj.CurrentContext
+	}
+	if name == "" {
+		return types.Context{}, nil
+	}
+
+	litmusContext, ok := config.FindContext(obj, name)
+	if !ok {
+		return types.Context{}, errors.New("context " + name + " not found, see `litmusctl config get-contexts`")
+	}
+	return litmusContext, nil
+}
+
+// GetProjectID returns the project ID passed with --project-id, falling back
+// to LITMUS_PROJECT_ID and then to the project of the active context. It is
+// empty when none of them is set.
+func GetProjectID(cmd *cobra.Command) (string, error) {
+	projectID, err := cmd.Flags().GetString("project-id")
+	if err != nil {
+		return "", err
+	}
+	if projectID == "" {
+		projectID = os.Getenv(ProjectIDEnv)
+	}
+	if projectID == "" {
+		litmusContext, err := GetContext(cmd)
+		if err != nil {
+			return "", err
+		}
+		projectID = litmusContext.ProjectID
+	}
+
+	return projectID, nil
+}
+
+// GetInfraID returns the infra ID passed with --chaos-infra-id, falling back
+// to the infra of the active context
+func GetInfraID(cmd *cobra.Command) (string, error) {
+	infraID, err := cmd.Flags().GetString("chaos-infra-id")
+	if err != nil {
+		return "", err
+	}
+	if infraID == "" {
+		litmusContext, err := GetContext(cmd)
+		if err != nil {
+			return "", err
+		}
+		infraID = litmusContext.InfraID
+	}
+
+	return infraID, nil
+}
+
+// GetKubeconfig returns the kubeconfig path passed with --kubeconfig, falling
+// back to the kubeconfig of the active context
+func GetKubeconfig(cmd *cobra.Command) (string, error) {
+	kubeconfig, err := cmd.Flags().GetString("kubeconfig")
+	if err != nil {
+		return "", err
+	}
+	if kubeconfig == "" {
+		litmusContext, err := GetContext(cmd)
+		if err != nil {
+			return "", err
+		}
+		kubeconfig = litmusContext.Kubeconfig
+	}
+
+	return kubeconfig, nil
+}
+
+// TokenExpired reports whether the token of the user has expired or expires
+// within tokenRefreshWindow. Users without a recorded expiry never expire.
+func TokenExpired(user types.User) bool {
+	expiresIn, err := strconv.ParseInt(user.ExpiresIn, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return time.Until(time.Unix(expiresIn, 0)) < tokenRefreshWindow
+}
+
+// renewToken renews the token of the user and stores it in the config file
+func renewToken(cmd *cobra.Command, configFilePath string, endpoint string, user types.User) (types.User, error) {
+	if Reauthenticate == nil {
+		return user, errors.New("session expired, run `litmusctl config set-account` to log in again")
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	user, err := Reauthenticate(ctx, endpoint, user)
+	if err != nil {
+		return user, err
+	}
+
+	return user, config.UpdateUserToken(endpoint, user, configFilePath)
+}
+
+// ApplyAccountSettings applies the request, TLS and proxy settings stored for
+// the account. Flags passed explicitly on the command line take precedence over them.
+func ApplyAccountSettings(cmd *cobra.Command, account types.Account) error {
+	if account.Retries != nil && !cmd.Flags().Changed("retries") {
+		config.RetryCount = *account.Retries
+	}
+
+	if account.RetryMaxWait != "" && !cmd.Flags().Changed("retry-max-wait") {
+		wait, err := time.ParseDuration(account.RetryMaxWait)
+		if err != nil {
+			return errors.New("invalid retryMaxWait for account " + account.Endpoint + ": " + err.Error())
+		}
+		config.RetryMaxWait = wait
+	}
+
+	if account.InsecureSkipVerify && !cmd.Flags().Changed("skipSSL") {
+		config.SkipSSLVerify = true
+	}
+	if account.CACert != "" && !cmd.Flags().Changed("cacert") {
+		config.CACert = account.CACert
+	}
+	if account.ClientCert != "" {
+		config.ClientCert = account.ClientCert
+	}
+	if account.ClientKey != "" {
+		config.ClientKey = account.ClientKey
+	}
+	if account.Proxy != "" {
+		config.Proxy = account.Proxy
+	}
+
+	return nil
+}
+
 func PrintInJsonFormat(inf interface{}) {
 	var out bytes.Buffer
 	byt, err := json.Marshal(inf)
@@ -1,8 +1,16 @@
 package utils
 
 import (
+	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/litmuschaos/litmusctl/pkg/config"
+	"github.com/litmuschaos/litmusctl/pkg/types"
+	"github.com/spf13/cobra"
 )
 
 func TestCheckKeyValueFormat(t *testing.T) {
@@ -238,3 +246,372 @@ func TestPrintInYamlFormat(t *testing.T) {
 		PrintInYamlFormat(data)
 	})
 }
+
+func TestTokenExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn string
+		want      bool
+	}{
+		{
+			name:      "expired token",
+			expiresIn: fmt.Sprint(time.Now().Add(-time.Hour).Unix()),
+			want:      true,
+		},
+		{
+			name:      "token expiring within the refresh window",
+			expiresIn: fmt.Sprint(time.Now().Add(time.Minute).Unix()),
+			want:      true,
+		},
+		{
+			name:      "valid token",
+			expiresIn: fmt.Sprint(time.Now().Add(time.Hour).Unix()),
+			want:      false,
+		},
+		{
+			name:      "no recorded expiry",
+			expiresIn: "",
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TokenExpired(types.User{ExpiresIn: tt.expiresIn})
+			if got != tt.want {
+				t.Errorf("TokenExpired(%q) = %v, want %v", tt.expiresIn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCredentialsFromEnv(t *testing.T) {
+	signed := func(username string, expiresAt time.Time) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"uid":      "uid-" + username,
+			"username": username,
+			"exp":      expiresAt.Unix(),
+		}).SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	validToken := signed("env-user", time.Now().Add(time.Hour))
+	fileToken := signed("admin", time.Now().Add(time.Hour))
+	otherToken := signed("dev", time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name       string
+		configFile bool
+		context    string
+		env        map[string]string
+		want       types.Credentials
+		wantErr    string
+	}{
+		{
+			name: "environment without config file",
+			env: map[string]string{
+				EndpointEnv: "https://litmus.example.com/",
+				TokenEnv:    validToken,
+			},
+			want: types.Credentials{
+				Username:       "env-user",
+				Token:          validToken,
+				Endpoint:       "https://litmus.example.com",
+				ServerEndpoint: "https://litmus.example.com",
+			},
+		},
+		{
+			name:       "environment takes precedence over config file",
+			configFile: true,
+			env: map[string]string{
+				EndpointEnv:       "https://litmus.example.com",
+				ServerEndpointEnv: "https://server.example.com",
+				TokenEnv:          validToken,
+				UsernameEnv:       "ci",
+			},
+			want: types.Credentials{
+				Username:       "ci",
+				Token:          validToken,
+				Endpoint:       "https://litmus.example.com",
+				ServerEndpoint: "https://server.example.com",
+			},
+		},
+		{
+			name:       "config file current user",
+			configFile: true,
+			want: types.Credentials{
+				Username:       "admin",
+				Token:          fileToken,
+				Endpoint:       "https://file.example.com",
+				ServerEndpoint: "https://file-server.example.com",
+			},
+		},
+		{
+			name:       "environment selects the user of the config file",
+			configFile: true,
+			env: map[string]string{
+				UsernameEnv:       "dev",
+				ServerEndpointEnv: "https://server.example.com",
+			},
+			want: types.Credentials{
+				Username:       "dev",
+				Token:          otherToken,
+				Endpoint:       "https://file.example.com",
+				ServerEndpoint: "https://server.example.com",
+			},
+		},
+		{
+			name:       "context flag takes precedence over environment",
+			configFile: true,
+			context:    "dev",
+			env: map[string]string{
+				EndpointEnv: "https://litmus.example.com",
+				TokenEnv:    validToken,
+				UsernameEnv: "ci",
+			},
+			want: types.Credentials{
+				Username:       "dev",
+				Token:          otherToken,
+				Endpoint:       "https://file.example.com",
+				ServerEndpoint: "https://file-server.example.com",
+			},
+		},
+		{
+			name:       "context flag without account keeps environment",
+			configFile: true,
+			context:    "project-only",
+			env:        map[string]string{UsernameEnv: "dev"},
+			want: types.Credentials{
+				Username:       "dev",
+				Token:          otherToken,
+				Endpoint:       "https://file.example.com",
+				ServerEndpoint: "https://file-server.example.com",
+			},
+		},
+		{
+			name:       "environment selects an unknown account",
+			configFile: true,
+			env:        map[string]string{EndpointEnv: "https://unknown.example.com"},
+			wantErr:    "no token found",
+		},
+		{
+			name:    "no config file and no environment",
+			wantErr: "no config file found",
+		},
+		{
+			name:    "token without endpoint",
+			env:     map[string]string{TokenEnv: validToken},
+			wantErr: EndpointEnv + " is not",
+		},
+		{
+			name: "expired token",
+			env: map[string]string{
+				EndpointEnv: "https://litmus.example.com",
+				TokenEnv:    signed("env-user", time.Now().Add(-time.Hour)),
+			},
+			wantErr: "has expired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{EndpointEnv, ServerEndpointEnv, TokenEnv, UsernameEnv} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			configFile := filepath.Join(t.TempDir(), ".litmusconfig")
+			if tt.configFile {
+				err := config.CreateNewLitmusCtlConfig(configFile, types.LitmuCtlConfig{
+					Accounts: []types.Account{{
+						Endpoint:       "https://file.example.com",
+						ServerEndpoint: "https://file-server.example.com",
+						Users: []types.User{
+							{Username: "admin", Token: fileToken},
+							{Username: "dev", Token: otherToken},
+						},
+					}},
+					Contexts: []types.Context{
+						{Name: "dev", Endpoint: "https://file.example.com", Username: "dev"},
+						{Name: "project-only", ProjectID: "project-1"},
+					},
+					CurrentAccount: "https://file.example.com",
+					CurrentUser:    "admin",
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("config", configFile, "")
+			cmd.Flags().String("context", tt.context, "")
+
+			got, err := GetCredentials(cmd)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetCredentials() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetCredentials() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetCredentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextDefaults(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ".litmusconfig")
+	err := config.CreateNewLitmusCtlConfig(configFile, types.LitmuCtlConfig{
+		Accounts: []types.Account{{
+			Endpoint: "https://file.example.com",
+			Users:    []types.User{{Username: "admin", Token: "token"}, {Username: "dev", Token: "dev-token"}},
+		}},
+		CurrentAccount: "https://file.example.com",
+		CurrentUser:    "admin",
+		Contexts: []types.Context{
+			{Name: "dev", Endpoint: "https://file.example.com", Username: "dev", ProjectID: "dev-project", InfraID: "dev-infra", Kubeconfig: "/dev/kubeconfig"},
+			{Name: "prod", Endpoint: "https://file.example.com", Username: "admin", ProjectID: "prod-project"},
+		},
+		CurrentContext: "prod",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		context        string
+		flags          map[string]string
+		env            string
+		wantProjectID  string
+		wantInfraID    string
+		wantKubeconfig string
+		wantUsername   string
+		wantErr        bool
+	}{
+		{
+			name:          "current context",
+			wantProjectID: "prod-project",
+			wantUsername:  "admin",
+		},
+		{
+			name:           "context flag",
+			context:        "dev",
+			wantProjectID:  "dev-project",
+			wantInfraID:    "dev-infra",
+			wantKubeconfig: "/dev/kubeconfig",
+			wantUsername:   "dev",
+		},
+		{
+			name:           "flags take precedence over the context",
+			context:        "dev",
+			flags:          map[string]string{"project-id": "flag-project", "chaos-infra-id": "flag-infra", "kubeconfig": "/flag/kubeconfig"},
+			wantProjectID:  "flag-project",
+			wantInfraID:    "flag-infra",
+			wantKubeconfig: "/flag/kubeconfig",
+			wantUsername:   "dev",
+		},
+		{
+			name:           "environment takes precedence over the context",
+			context:        "dev",
+			env:            "env-project",
+			wantProjectID:  "env-project",
+			wantInfraID:    "dev-infra",
+			wantKubeconfig: "/dev/kubeconfig",
+			wantUsername:   "dev",
+		},
+		{
+			name:    "unknown context",
+			context: "unknown",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{EndpointEnv, ServerEndpointEnv, TokenEnv, UsernameEnv} {
+				t.Setenv(name, "")
+			}
+			t.Setenv(ProjectIDEnv, tt.env)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("config", configFile, "")
+			cmd.Flags().String("context", tt.context, "")
+			for _, flag := range []string{"project-id", "chaos-infra-id", "kubeconfig"} {
+				cmd.Flags().String(flag, tt.flags[flag], "")
+			}
+
+			cred, err := GetCredentials(cmd)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("GetCredentials() expected an error for an unknown context")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetCredentials() unexpected error = %v", err)
+			}
+			if cred.Username != tt.wantUsername {
+				t.Errorf("GetCredentials() username = %q, want %q", cred.Username, tt.wantUsername)
+			}
+
+			for _, got := range []struct {
+				name string
+				get  func(*cobra.Command) (string, error)
+				want string
+			}{
+				{name: "GetProjectID", get: GetProjectID, want: tt.wantProjectID},
+				{name: "GetInfraID", get: GetInfraID, want: tt.wantInfraID},
+				{name: "GetKubeconfig", get: GetKubeconfig, want: tt.wantKubeconfig},
+			} {
+				value, err := got.get(cmd)
+				if err != nil {
+					t.Fatalf("%s() unexpected error = %v", got.name, err)
+				}
+				if value != got.want {
+					t.Errorf("%s() = %q, want %q", got.name, value, got.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetProjectID(t *testing.T) {
+	tests := []struct {
+		name string
+		flag string
+		env  string
+		want string
+	}{
+		{name: "flag", flag: "flag-project", env: "env-project", want: "flag-project"},
+		{name: "environment", env: "env-project", want: "env-project"},
+		{name: "neither", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProjectIDEnv, tt.env)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("config", filepath.Join(t.TempDir(), ".litmusconfig"), "")
+			cmd.Flags().String("project-id", "", "")
+			if tt.flag != "" {
+				cmd.Flags().Set("project-id", tt.flag)
+			}
+
+			got, err := GetProjectID(cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("GetProjectID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -68,3 +68,22 @@ const (
 	// Auth server api path
 	AuthAPIPath = "/auth"
 )
+
+// Environment variables read in place of the config file. Flags take
+// precedence over them, and they take precedence over the config file.
+const (
+	// ChaosCenter endpoint, used with TokenEnv when there is no config file
+	EndpointEnv = "LITMUS_ENDPOINT"
+
+	// Server endpoint, defaults to the ChaosCenter endpoint
+	ServerEndpointEnv = "LITMUS_SERVER_ENDPOINT"
+
+	// Token of the user, an access token or an API token
+	TokenEnv = "LITMUS_TOKEN"
+
+	// Username, defaults to the one carried by the token
+	UsernameEnv = "LITMUS_USERNAME"
+
+	// Project ID used when --project-id is not passed
+	ProjectIDEnv = "LITMUS_PROJECT_ID"
+)
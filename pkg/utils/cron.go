@@ -0,0 +1,122 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// cronMacros are the schedules Argo accepts besides five fields, with their
+// equivalent expression
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronSchedule is the schedule of a CronWorkflow manifest
+type CronSchedule struct {
+	Schedule  string
+	Timezone  string
+	Suspended bool
+}
+
+// ValidateCronSyntax checks a schedule the way Argo reads it: five fields,
+// a macro such as @daily, or @every with a duration
+func ValidateCronSyntax(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if schedule == "" {
+		return errors.New("empty schedule")
+	}
+	if duration, ok := strings.CutPrefix(schedule, "@every "); ok {
+		_, err := time.ParseDuration(strings.TrimSpace(duration))
+		return err
+	}
+	if strings.HasPrefix(schedule, "@") {
+		if _, ok := cronMacros[schedule]; !ok {
+			return errors.New("unknown macro " + schedule)
+		}
+		return nil
+	}
+	if fields := len(strings.Fields(schedule)); fields != 5 {
+		return errors.New("expected 5 fields, found " + strconv.Itoa(fields))
+	}
+	_, err := cronexpr.Parse(schedule)
+	return err
+}
+
+// NextCronTime returns the next time the schedule fires after now, in the
+// timezone of the schedule, or the local one when it is empty
+func NextCronTime(schedule string, timezone string, now time.Time) (time.Time, error) {
+	if err := ValidateCronSyntax(schedule); err != nil {
+		return time.Time{}, err
+	}
+
+	location := time.Local
+	if timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	schedule = strings.TrimSpace(schedule)
+	if duration, ok := strings.CutPrefix(schedule, "@every "); ok {
+		interval, _ := time.ParseDuration(strings.TrimSpace(duration))
+		return now.Add(interval).In(location), nil
+	}
+	if expression, ok := cronMacros[schedule]; ok {
+		schedule = expression
+	}
+
+	return cronexpr.MustParse(schedule).Next(now.In(location)), nil
+}
+
+// CronWorkflowSchedule returns the schedule of a CronWorkflow manifest, in
+// JSON or YAML
+func CronWorkflowSchedule(manifest []byte) (CronSchedule, error) {
+	var cronWorkflow struct {
+		Spec struct {
+			Schedule  string   `json:"schedule"`
+			Schedules []string `json:"schedules"`
+			Timezone  string   `json:"timezone"`
+			Suspend   bool     `json:"suspend"`
+		} `json:"spec"`
+	}
+	if err := UnmarshalObject(manifest, &cronWorkflow); err != nil {
+		return CronSchedule{}, err
+	}
+
+	schedule := CronSchedule{
+		Schedule:  cronWorkflow.Spec.Schedule,
+		Timezone:  cronWorkflow.Spec.Timezone,
+		Suspended: cronWorkflow.Spec.Suspend,
+	}
+	if schedule.Schedule == "" && len(cronWorkflow.Spec.Schedules) > 0 {
+		schedule.Schedule = cronWorkflow.Spec.Schedules[0]
+	}
+
+	return schedule, nil
+}
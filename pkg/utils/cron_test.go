@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateCronSyntax(t *testing.T) {
+	tests := []struct {
+		schedule string
+		wantErr  bool
+	}{
+		{"0 */2 * * *", false},
+		{"30 9 * * 1-5", false},
+		{"@daily", false},
+		{"@every 90m", false},
+		{"", true},
+		{"0 */2 * *", true},
+		{"0 0 */2 * * * *", true},
+		{"61 * * * *", true},
+		{"@sometimes", true},
+		{"@every soon", true},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateCronSyntax(tt.schedule); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateCronSyntax(%q) error = %v, wantErr %v", tt.schedule, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNextCronTime(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		schedule string
+		timezone string
+		want     string
+		wantErr  bool
+	}{
+		{"0 2 * * *", "UTC", "2024-03-11T02:00:00Z", false},
+		{"0 2 * * *", "Asia/Tokyo", "2024-03-12T02:00:00+09:00", false},
+		{"@midnight", "UTC", "2024-03-11T00:00:00Z", false},
+		{"@hourly", "UTC", "2024-03-10T23:00:00Z", false},
+		{"@every 90m", "UTC", "2024-03-11T00:00:00Z", false},
+		{"0 2 * * *", "Mars/Olympus", "", true},
+		{"0 2 * *", "UTC", "", true},
+	}
+
+	for _, tt := range tests {
+		next, err := NextCronTime(tt.schedule, tt.timezone, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NextCronTime(%q, %q) error = %v, wantErr %v", tt.schedule, tt.timezone, err, tt.wantErr)
+			continue
+		}
+		if err == nil && next.Format(time.RFC3339) != tt.want {
+			t.Errorf("NextCronTime(%q, %q) = %s, want %s", tt.schedule, tt.timezone, next.Format(time.RFC3339), tt.want)
+		}
+	}
+}
+
+func TestCronWorkflowSchedule(t *testing.T) {
+	schedule, err := CronWorkflowSchedule([]byte(`{"kind":"CronWorkflow","spec":{"schedule":"0 2 * * *","timezone":"Europe/Paris","suspend":true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (CronSchedule{Schedule: "0 2 * * *", Timezone: "Europe/Paris", Suspended: true}); schedule != want {
+		t.Errorf("CronWorkflowSchedule() = %+v, want %+v", schedule, want)
+	}
+
+	schedule, err = CronWorkflowSchedule([]byte("kind: CronWorkflow\nspec:\n  schedules:\n    - \"@daily\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Schedule != "@daily" || schedule.Suspended {
+		t.Errorf("CronWorkflowSchedule() = %+v, want the first of the schedules", schedule)
+	}
+}
@@ -37,21 +37,34 @@ import (
 // populates the payload for the Message API request. The manifest
 // can be either a local file or a remote file.
 func ParseExperimentManifest(file string, chaosWorkFlowRequest *model.SaveChaosExperimentRequest) error {
-
-	var body []byte
-	var err error
-
-	// Read the manifest file.
-	parsedURL, ok := url.ParseRequestURI(file)
-	if ok != nil || !(parsedURL.Scheme == "http" || parsedURL.Scheme == "https") {
-		body, err = os.ReadFile(file)
-	} else {
-		body, err = ReadRemoteFile(file)
-	}
+	body, err := ReadManifest(file)
 	if err != nil {
 		return err
 	}
 
+	return ParseExperimentManifestData(body, chaosWorkFlowRequest)
+}
+
+// ReadManifest reads a manifest from a local file or from an http(s) URL
+func ReadManifest(file string) ([]byte, error) {
+	if IsRemoteManifest(file) {
+		return ReadRemoteFile(file)
+	}
+
+	return os.ReadFile(file)
+}
+
+// IsRemoteManifest reports whether the manifest is given as an http(s) URL
+func IsRemoteManifest(file string) bool {
+	parsedURL, err := url.ParseRequestURI(file)
+	return err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https")
+}
+
+// ParseExperimentManifestData parses a Workflow or CronWorkflow manifest and
+// populates the payload for the Message API request
+func ParseExperimentManifestData(body []byte, chaosWorkFlowRequest *model.SaveChaosExperimentRequest) error {
+	var err error
+
 	// Extract the kind of Argo Workflow from the given manifest
 	re := regexp.MustCompile(`\bkind:\s*(?P<kind>Workflow|CronWorkflow)\b`)
 	extractKind := fmt.Sprintf("${%s}", re.SubexpNames()[1])
@@ -83,6 +96,8 @@ func ParseExperimentManifest(file string, chaosWorkFlowRequest *model.SaveChaosE
 
 		chaosWorkFlowRequest.Manifest = strings.Replace(string(workflowStr), "\"generateName\":\"TOBEDELETED\",", "", 1)
 		//chaosWorkFlowRequest.IsCustomWorkflow = true
+		experimentType := model.ExperimentTypeExperiment
+		chaosWorkFlowRequest.Type = &experimentType
 
 		// Fetch the weightages for experiments present in the spec.
 		err = FetchWeightages(chaosWorkFlowRequest, workflow.Spec.Templates)
@@ -109,6 +124,15 @@ func ParseExperimentManifest(file string, chaosWorkFlowRequest *model.SaveChaosE
 			return errors.New("No name or generateName provided for the Chaos experiment.")
 		}
 
+		// The server reads the schedule from spec.schedule only
+		if cronWorkflow.Spec.Schedule == "" && len(cronWorkflow.Spec.Schedules) > 0 {
+			cronWorkflow.Spec.Schedule = cronWorkflow.Spec.Schedules[0]
+			cronWorkflow.Spec.Schedules = nil
+		}
+		if err = ValidateCronSyntax(cronWorkflow.Spec.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule %q: %w", cronWorkflow.Spec.Schedule, err)
+		}
+
 		// Marshal the workflow back to JSON for API payload.
 		workflowStr, ok := json.Marshal(cronWorkflow)
 		if ok != nil {
@@ -118,8 +142,9 @@ func ParseExperimentManifest(file string, chaosWorkFlowRequest *model.SaveChaosE
 		chaosWorkFlowRequest.Manifest = strings.Replace(string(workflowStr), "\"generateName\":\"TOBEDELETED\",", "", 1)
 		//chaosWorkFlowRequest.IsCustomWorkflow = true
 
-		// Set the schedule for the workflow
-		//chaosWorkFlowRequest.CronSyntax = cronWorkflow.Spec.Schedule
+		// The server takes the cron syntax from the schedule in the manifest
+		experimentType := model.ExperimentTypeCronExperiment
+		chaosWorkFlowRequest.Type = &experimentType
 
 		// Fetch the weightages for experiments present in the spec.
 		err = FetchWeightages(chaosWorkFlowRequest, cronWorkflow.Spec.WorkflowSpec.Templates)
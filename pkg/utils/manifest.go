@@ -0,0 +1,328 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// templateOpen and templateClose stand in for the {{ }} of Argo templates
+// while parsing ChaosEngine artifacts, which are not valid YAML otherwise
+const (
+	templateOpen  = "__LITMUSCTL_TEMPLATE_OPEN__"
+	templateClose = "__LITMUSCTL_TEMPLATE_CLOSE__"
+)
+
+var (
+	documentSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+	// generatedSuffix is the suffix ParseExperimentManifest adds to generateName
+	generatedSuffix = regexp.MustCompile(`^[a-z0-9]{5}$`)
+)
+
+// ExpandManifestPaths expands the files, directories, globs and URLs given
+// with -f into the list of manifests to read. Directories are expanded to the
+// .yaml, .yml and .json files they contain.
+func ExpandManifestPaths(paths []string) ([]string, error) {
+	var manifests []string
+	for _, path := range paths {
+		if IsRemoteManifest(path) {
+			manifests = append(manifests, path)
+			continue
+		}
+
+		matches := []string{path}
+		if strings.ContainsAny(path, "*?[") {
+			var err error
+			matches, err = filepath.Glob(path)
+			if err != nil {
+				return nil, errors.New("invalid pattern " + path + ": " + err.Error())
+			}
+			if len(matches) == 0 {
+				return nil, errors.New("no manifest matches " + path)
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				manifests = append(manifests, match)
+				continue
+			}
+
+			entries, err := os.ReadDir(match)
+			if err != nil {
+				return nil, err
+			}
+			var files []string
+			for _, entry := range entries {
+				switch strings.ToLower(filepath.Ext(entry.Name())) {
+				case ".yaml", ".yml", ".json":
+					if !entry.IsDir() {
+						files = append(files, filepath.Join(match, entry.Name()))
+					}
+				}
+			}
+			sort.Strings(files)
+			manifests = append(manifests, files...)
+		}
+	}
+
+	return manifests, nil
+}
+
+// SplitManifests splits a multi-document YAML manifest into its documents,
+// leaving out the documents that hold only comments
+func SplitManifests(body []byte) [][]byte {
+	var documents [][]byte
+	for _, document := range documentSeparator.Split(string(body), -1) {
+		for _, line := range strings.Split(document, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				documents = append(documents, []byte(document))
+				break
+			}
+		}
+	}
+
+	return documents
+}
+
+// ManifestName returns the name and the generateName of the manifest
+func ManifestName(body []byte) (string, string, error) {
+	var object struct {
+		Metadata struct {
+			Name         string `json:"name"`
+			GenerateName string `json:"generateName"`
+		} `json:"metadata"`
+	}
+	if err := UnmarshalObject(body, &object); err != nil {
+		return "", "", err
+	}
+
+	return object.Metadata.Name, object.Metadata.GenerateName, nil
+}
+
+// IsGeneratedName reports whether name was generated from generateName by
+// ParseExperimentManifest
+func IsGeneratedName(name string, generateName string) bool {
+	suffix, ok := strings.CutPrefix(name, generateName)
+	return ok && generatedSuffix.MatchString(suffix)
+}
+
+// SetManifestName returns the manifest, in YAML, with its metadata.name set
+// to name in place of its generateName
+func SetManifestName(body []byte, name string) ([]byte, error) {
+	var object map[string]interface{}
+	if err := UnmarshalObject(body, &object); err != nil {
+		return nil, err
+	}
+
+	metadata, ok := object["metadata"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("no metadata found in the manifest")
+	}
+	metadata["name"] = name
+	delete(metadata, "generateName")
+
+	return yaml.Marshal(object)
+}
+
+// NormalizeManifest parses a YAML or JSON manifest, along with the
+// ChaosEngines embedded as raw artifacts, so that manifests can be compared
+// regardless of their format and key order
+func NormalizeManifest(manifest []byte) (interface{}, error) {
+	var object interface{}
+	if err := UnmarshalObject(manifest, &object); err != nil {
+		return nil, err
+	}
+
+	return normalizeValue(object), nil
+}
+
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeValue(item)
+		}
+		if raw, ok := v["raw"].(map[string]interface{}); ok {
+			if data, ok := raw["data"].(string); ok {
+				raw["data"] = parseArtifact(data)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeValue(item)
+		}
+	}
+
+	return value
+}
+
+// parseArtifact parses the YAML data of a raw artifact, keeping the Argo
+// templates it holds. Data that isn't YAML is returned as is.
+func parseArtifact(data string) interface{} {
+	escaped := strings.NewReplacer("{{", templateOpen, "}}", templateClose).Replace(data)
+
+	var artifact interface{}
+	if err := yaml.Unmarshal([]byte(escaped), &artifact); err != nil || artifact == nil {
+		return data
+	}
+
+	return restoreTemplates(artifact)
+}
+
+func restoreTemplates(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.NewReplacer(templateOpen, "{{", templateClose, "}}").Replace(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = restoreTemplates(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = restoreTemplates(item)
+		}
+	}
+
+	return value
+}
+
+// ManifestsEqual reports whether the existing manifest of an experiment
+// matches the desired one once aligned with AlignManifests
+func ManifestsEqual(existing []byte, desired []byte) (bool, error) {
+	existingObject, desiredObject, err := AlignManifests(existing, desired)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(existingObject, desiredObject), nil
+}
+
+// AlignManifests normalizes the existing and desired manifests, and leaves
+// out the fields that don't make them differ: empty fields, the labels
+// ChaosCenter adds when an experiment is saved, and the suffix of a name
+// generated from the generateName of the desired manifest
+func AlignManifests(existing []byte, desired []byte) (interface{}, interface{}, error) {
+	existingObject, err := NormalizeManifest(existing)
+	if err != nil {
+		return nil, nil, err
+	}
+	desiredObject, err := NormalizeManifest(desired)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stripGeneratedName(existingObject, desiredObject)
+	align(existingObject, desiredObject, false)
+
+	return existingObject, desiredObject, nil
+}
+
+// stripGeneratedName replaces the name of the existing manifest by the
+// generateName of the desired one, when the name was generated from it
+func stripGeneratedName(existing interface{}, desired interface{}) {
+	existingMeta, _ := mapValue(existing)["metadata"].(map[string]interface{})
+	desiredMeta, _ := mapValue(desired)["metadata"].(map[string]interface{})
+	if existingMeta == nil || desiredMeta == nil {
+		return
+	}
+
+	name, _ := existingMeta["name"].(string)
+	generateName, _ := desiredMeta["generateName"].(string)
+	if isEmptyValue(desiredMeta["name"]) && generateName != "" && IsGeneratedName(name, generateName) {
+		delete(existingMeta, "name")
+		existingMeta["generateName"] = generateName
+	}
+}
+
+func mapValue(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+// align removes the fields of existing and desired that only one of them
+// sets to an empty value, and the labels only existing has
+func align(existing interface{}, desired interface{}, labels bool) {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		e, ok := existing.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, value := range e {
+			if _, ok := d[key]; !ok && (labels || key == "labels" || isEmptyValue(value)) {
+				delete(e, key)
+			}
+		}
+		for key, value := range d {
+			if _, ok := e[key]; !ok && isEmptyValue(value) {
+				delete(d, key)
+			}
+		}
+		for key := range d {
+			if _, ok := e[key]; !ok {
+				continue
+			}
+			if isEmptyValue(e[key]) && isEmptyValue(d[key]) {
+				delete(e, key)
+				delete(d, key)
+				continue
+			}
+			align(e[key], d[key], key == "labels")
+		}
+	case []interface{}:
+		e, ok := existing.([]interface{})
+		if !ok || len(e) != len(d) {
+			return
+		}
+		for i := range d {
+			align(e[i], d[i], false)
+		}
+	}
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		for _, item := range v {
+			if !isEmptyValue(item) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
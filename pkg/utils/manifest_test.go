@@ -0,0 +1,199 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testWorkflow = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: pod-delete
+  namespace: litmus
+spec:
+  entrypoint: pod-delete
+  templates:
+    - name: pod-delete
+      inputs:
+        artifacts:
+          - name: pod-delete
+            path: /tmp/chaosengine.yaml
+            raw:
+              data: |
+                apiVersion: litmuschaos.io/v1alpha1
+                kind: ChaosEngine
+                metadata:
+                  namespace: {{workflow.parameters.adminModeNamespace}}
+                  generateName: pod-delete
+                spec:
+                  appinfo:
+                    appns: default
+                  experiments:
+                    - name: pod-delete
+`
+
+func TestExpandManifestPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.yaml", "a.yml", "c.json", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "nested.yaml"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		paths   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "directory",
+			paths: []string{dir},
+			want:  []string{filepath.Join(dir, "a.yml"), filepath.Join(dir, "b.yaml"), filepath.Join(dir, "c.json")},
+		},
+		{
+			name:  "glob, file and URL",
+			paths: []string{filepath.Join(dir, "*.yaml"), filepath.Join(dir, "notes.txt"), "https://example.com/experiment.yaml"},
+			want:  []string{filepath.Join(dir, "b.yaml"), filepath.Join(dir, "notes.txt"), "https://example.com/experiment.yaml"},
+		},
+		{
+			name:    "glob without match",
+			paths:   []string{filepath.Join(dir, "*.tmpl")},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			paths:   []string{filepath.Join(dir, "missing.yaml")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandManifestPaths(tt.paths)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandManifestPaths() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpandManifestPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitManifests(t *testing.T) {
+	body := "# experiments\n---\nkind: Workflow\n---\n# only a comment\n---   \nkind: CronWorkflow\n"
+
+	documents := SplitManifests([]byte(body))
+	if len(documents) != 2 {
+		t.Fatalf("SplitManifests() returned %d documents, want 2", len(documents))
+	}
+	if !strings.Contains(string(documents[0]), "Workflow") || !strings.Contains(string(documents[1]), "CronWorkflow") {
+		t.Errorf("SplitManifests() = %q", documents)
+	}
+}
+
+func TestIsGeneratedName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "pod-delete-x1y2z", want: true},
+		{name: "pod-delete-", want: false},
+		{name: "pod-delete-x1y2z3", want: false},
+		{name: "pod-delete-X1Y2Z", want: false},
+		{name: "other-x1y2z", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsGeneratedName(tt.name, "pod-delete-"); got != tt.want {
+			t.Errorf("IsGeneratedName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetManifestName(t *testing.T) {
+	manifest := "kind: Workflow\nmetadata:\n  generateName: pod-delete-\nspec:\n  templates:\n    - name: pod-delete-x1y2z\n"
+	got, err := SetManifestName([]byte(manifest), "pod-delete-x1y2z")
+	if err != nil {
+		t.Fatalf("SetManifestName() error = %v", err)
+	}
+
+	name, generateName, err := ManifestName(got)
+	if err != nil || name != "pod-delete-x1y2z" || generateName != "" {
+		t.Errorf("ManifestName() = %q, %q, %v, want the name set and no generateName", name, generateName, err)
+	}
+	if !strings.Contains(string(got), "- name: pod-delete-x1y2z") {
+		t.Errorf("SetManifestName() = %s, want the templates unchanged", got)
+	}
+}
+
+func TestManifestsEqual(t *testing.T) {
+	// The manifest as stored by ChaosCenter: JSON, with labels added to the
+	// workflow and the ChaosEngine, and the ChaosEngine marshalled again
+	stored := `{"apiVersion":"argoproj.io/v1alpha1","kind":"Workflow","metadata":{"name":"pod-delete","namespace":"litmus","creationTimestamp":null,
+"labels":{"infra_id":"i1","workflow_id":"w1"}},"spec":{"entrypoint":"pod-delete","arguments":{},"templates":[{"name":"pod-delete","inputs":{"artifacts":[{"name":"pod-delete",
+"path":"/tmp/chaosengine.yaml","raw":{"data":"apiVersion: litmuschaos.io/v1alpha1\nkind: ChaosEngine\nmetadata:\n  generateName: pod-delete\n  labels:\n    workflow_run_id: '{{ workflow.uid }}'\n  namespace: '{{workflow.parameters.adminModeNamespace}}'\nspec:\n  appinfo:\n    appns: default\n  experiments:\n  - name: pod-delete\n"}}]}}]},"status":{}}`
+
+	tests := []struct {
+		name    string
+		desired string
+		want    bool
+	}{
+		{
+			name:    "same experiment",
+			desired: testWorkflow,
+			want:    true,
+		},
+		{
+			name:    "changed ChaosEngine",
+			desired: strings.Replace(testWorkflow, "appns: default", "appns: checkout", 1),
+			want:    false,
+		},
+		{
+			name:    "removed field",
+			desired: strings.Replace(testWorkflow, "  namespace: litmus\n", "", 1),
+			want:    false,
+		},
+		{
+			name:    "added label",
+			desired: strings.Replace(testWorkflow, "  namespace: litmus\n", "  namespace: litmus\n  labels:\n    team: checkout\n", 1),
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ManifestsEqual([]byte(stored), []byte(tt.desired))
+			if err != nil {
+				t.Fatalf("ManifestsEqual() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ManifestsEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlignManifests(t *testing.T) {
+	existing := `{"metadata":{"name":"pod-delete-ab12c","labels":{"infra_id":"i1"},"creationTimestamp":null},"spec":{"arguments":{}}}`
+	desired := "metadata:\n  generateName: pod-delete-\nspec:\n  arguments: {}\n  entrypoint: \"\"\n"
+
+	existingObject, desiredObject, err := AlignManifests([]byte(existing), []byte(desired))
+	if err != nil {
+		t.Fatalf("AlignManifests() unexpected error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"metadata": map[string]interface{}{"generateName": "pod-delete-"},
+	}
+	if !reflect.DeepEqual(existingObject, want) || !reflect.DeepEqual(desiredObject, want) {
+		t.Errorf("AlignManifests() = %v, %v, want both %v", existingObject, desiredObject, want)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/litmuschaos/litmusctl/pkg/config"
 	"sigs.k8s.io/yaml"
 )
 
@@ -40,9 +41,16 @@ func UnmarshalObject(body []byte, obj interface{}) error {
 	return err
 }
 
-// ReadRemoteFile reads a given remote file.
+// ReadRemoteFile reads a given remote file. --skipSSL and --cacert apply, but
+// the client certificate and proxy of the ChaosCenter account are not used,
+// as the file is usually hosted elsewhere.
 func ReadRemoteFile(url string) ([]byte, error) {
-	resp, err := http.Get(url)
+	transport, err := config.RemoteFileTransport()
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: transport, Timeout: config.RequestTimeout}
+	resp, err := client.Get(url)
 	var body []byte
 	if err == nil {
 		body, err = io.ReadAll(resp.Body)
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/litmuschaos/litmusctl/pkg/config"
+)
+
+func TestReadRemoteFileSkipsAccountProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("kind: Workflow\n"))
+	}))
+	defer server.Close()
+
+	// The proxy of the ChaosCenter account can't be reached
+	proxy := config.Proxy
+	config.Proxy = "http://127.0.0.1:1"
+	defer func() { config.Proxy = proxy }()
+
+	body, err := ReadRemoteFile(server.URL + "/experiment.yaml")
+	if err != nil {
+		t.Fatalf("ReadRemoteFile() error = %v", err)
+	}
+	if string(body) != "kind: Workflow\n" {
+		t.Errorf("ReadRemoteFile() = %q, want the manifest", body)
+	}
+}
+
+func TestReadRemoteFileSkipSSLVerify(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("kind: Workflow\n"))
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	// The certificate of the server isn't trusted
+	if _, err := ReadRemoteFile(server.URL + "/experiment.yaml"); err == nil {
+		t.Fatal("ReadRemoteFile() from an untrusted server succeeded, want an error")
+	}
+
+	skipSSLVerify := config.SkipSSLVerify
+	config.SkipSSLVerify = true
+	defer func() { config.SkipSSLVerify = skipSSLVerify }()
+
+	body, err := ReadRemoteFile(server.URL + "/experiment.yaml")
+	if err != nil {
+		t.Fatalf("ReadRemoteFile() with SkipSSLVerify error = %v", err)
+	}
+	if string(body) != "kind: Workflow\n" {
+		t.Errorf("ReadRemoteFile() = %q, want the manifest", body)
+	}
+}
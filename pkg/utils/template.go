@@ -0,0 +1,278 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// valuePlaceholder matches the placeholders of manifest templates, like
+	// {{ .Values.target.ns }}. Argo expressions, like {{workflow.name}},
+	// never start with a dot and are left untouched.
+	valuePlaceholder = regexp.MustCompile(`\{\{-?\s*\.Values\.([A-Za-z0-9_\-]+(?:\.[A-Za-z0-9_\-]+)*)\s*-?\}\}`)
+	// templatePlaceholder matches any placeholder meant for litmusctl
+	templatePlaceholder = regexp.MustCompile(`\{\{-?\s*\.[^}]*\}\}`)
+)
+
+// LoadValues merges the values files, in order, then the --set overrides
+// given as path=value, such as target.ns=checkout. Override values are read
+// as YAML scalars, so replicas=3 sets a number and suspend=true a boolean,
+// while a quoted value such as tag="1.10" stays a string.
+func LoadValues(files []string, sets []string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, file := range files {
+		data, err := ReadManifest(file)
+		if err != nil {
+			return nil, err
+		}
+		var fileValues map[string]interface{}
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, errors.New("invalid values file " + file + ": " + err.Error())
+		}
+		mergeValues(values, fileValues)
+	}
+
+	for _, set := range sets {
+		path, value, ok := strings.Cut(set, "=")
+		if !ok || path == "" {
+			return nil, errors.New("invalid value " + set + ", use path=value")
+		}
+		if err := setValue(values, strings.Split(path, "."), scalarValue(value)); err != nil {
+			return nil, errors.New("invalid value " + set + ": " + err.Error())
+		}
+	}
+
+	return values, nil
+}
+
+// mergeValues merges src into dst, recursing into the maps both define
+func mergeValues(dst map[string]interface{}, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// scalarValue parses a --set value as a YAML scalar. Values that aren't a
+// scalar, or are null, are kept as they were given.
+func scalarValue(value string) interface{} {
+	var scalar interface{}
+	if err := yaml.Unmarshal([]byte(value), &scalar); err != nil {
+		return value
+	}
+	switch scalar.(type) {
+	case string, bool, float64:
+		return scalar
+	default:
+		return value
+	}
+}
+
+func setValue(values map[string]interface{}, path []string, value interface{}) error {
+	for _, key := range path[:len(path)-1] {
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			if _, exists := values[key]; exists {
+				return errors.New(key + " is not a map")
+			}
+			next = map[string]interface{}{}
+			values[key] = next
+		}
+		values = next
+	}
+	values[path[len(path)-1]] = value
+
+	return nil
+}
+
+// lookupValue returns the value at the dotted path
+func lookupValue(values map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = values
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// stringValue formats a value substituted into a longer string. Maps and
+// lists are formatted as JSON.
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// RenderManifest substitutes the {{ .Values.path }} placeholders of the
+// manifest. The placeholders are substituted in the parsed manifest, so
+// values are quoted as needed: a placeholder that is a whole unquoted value
+// takes the type of its value, any other is substituted as a string. The
+// raw artifacts of the embedded ChaosEngines are rendered the same way.
+// Placeholders that can't be resolved are all reported in the error, with
+// their line in the template.
+func RenderManifest(body []byte, values map[string]interface{}) ([]byte, error) {
+	text := string(body)
+
+	var unresolved []string
+	for _, match := range templatePlaceholder.FindAllStringIndex(text, -1) {
+		placeholder := text[match[0]:match[1]]
+		if submatch := valuePlaceholder.FindStringSubmatch(placeholder); submatch != nil && submatch[0] == placeholder {
+			if value, ok := lookupValue(values, submatch[1]); ok && value != nil {
+				continue
+			}
+		}
+		line := strings.Count(text[:match[0]], "\n") + 1
+		unresolved = append(unresolved, "line "+strconv.Itoa(line)+": "+placeholder)
+	}
+	if len(unresolved) > 0 {
+		return nil, errors.New("unresolved variables in the manifest:\n  " + strings.Join(unresolved, "\n  "))
+	}
+
+	// Placeholders aren't valid YAML, they are swapped for tokens before
+	// the manifest is parsed
+	var tokenValues []interface{}
+	text = valuePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		value, _ := lookupValue(values, valuePlaceholder.FindStringSubmatch(placeholder)[1])
+		tokenValues = append(tokenValues, value)
+		return "litmusctl_value_" + strconv.Itoa(len(tokenValues)-1) + "_"
+	})
+	if len(tokenValues) == 0 {
+		return body, nil
+	}
+
+	return renderDocuments(text, tokenValues)
+}
+
+// valueToken matches the tokens placeholders are swapped for
+var valueToken = regexp.MustCompile(`litmusctl_value_([0-9]+)_`)
+
+// renderDocuments substitutes the tokens of the YAML documents of text
+func renderDocuments(text string, tokenValues []interface{}) ([]byte, error) {
+	var documents []*yamlv3.Node
+	decoder := yamlv3.NewDecoder(strings.NewReader(text))
+	for {
+		var document yamlv3.Node
+		if err := decoder.Decode(&document); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.New("invalid manifest template: " + err.Error())
+		}
+		documents = append(documents, &document)
+	}
+
+	var buf bytes.Buffer
+	encoder := yamlv3.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	for _, document := range documents {
+		if err := renderNode(document, tokenValues); err != nil {
+			return nil, err
+		}
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderNode(node *yamlv3.Node, tokenValues []interface{}) error {
+	replaceTokens := func(s string) string {
+		return valueToken.ReplaceAllStringFunc(s, func(token string) string {
+			index, _ := strconv.Atoi(valueToken.FindStringSubmatch(token)[1])
+			return stringValue(tokenValues[index])
+		})
+	}
+	node.HeadComment = replaceTokens(node.HeadComment)
+	node.LineComment = replaceTokens(node.LineComment)
+	node.FootComment = replaceTokens(node.FootComment)
+
+	if node.Kind != yamlv3.ScalarNode {
+		for i, child := range node.Content {
+			// Mapping keys are always strings
+			if node.Kind == yamlv3.MappingNode && i%2 == 0 {
+				child.Value = replaceTokens(child.Value)
+				continue
+			}
+			if err := renderNode(child, tokenValues); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !valueToken.MatchString(node.Value) {
+		return nil
+	}
+
+	// A whole unquoted placeholder takes the type of its value
+	if submatch := valueToken.FindStringSubmatch(node.Value); submatch[0] == node.Value && node.Style == 0 {
+		index, _ := strconv.Atoi(submatch[1])
+		return node.Encode(tokenValues[index])
+	}
+
+	// Block scalars holding YAML, like the ChaosEngines of raw artifacts,
+	// are rendered as YAML
+	if node.Style&(yamlv3.LiteralStyle|yamlv3.FoldedStyle) != 0 {
+		var document yamlv3.Node
+		if err := yamlv3.Unmarshal([]byte(node.Value), &document); err == nil && len(document.Content) > 0 && document.Content[0].Kind != yamlv3.ScalarNode {
+			rendered, err := renderDocuments(node.Value, tokenValues)
+			if err != nil {
+				return err
+			}
+			node.Value = string(rendered)
+			return nil
+		}
+	}
+
+	node.Value = replaceTokens(node.Value)
+	node.Tag = "!!str"
+
+	return nil
+}
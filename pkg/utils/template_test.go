@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"sigs.k8s.io/yaml"
+)
+
+const testTemplate = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: pod-delete-{{ .Values.target.app }}
+  namespace: litmus
+spec:
+  entrypoint: pod-delete
+  arguments:
+    parameters:
+      - name: adminModeNamespace
+        value: litmus
+  templates:
+    - name: pod-delete
+      metadata:
+        labels:
+          weight: "10"
+      inputs:
+        artifacts:
+          - name: pod-delete
+            path: /tmp/chaosengine.yaml
+            raw:
+              data: |
+                apiVersion: litmuschaos.io/v1alpha1
+                kind: ChaosEngine
+                metadata:
+                  generateName: pod-delete
+                  namespace: "{{workflow.parameters.adminModeNamespace}}"
+                  labels:
+                    workflow_run_id: "{{workflow.uid}}"
+                spec:
+                  appinfo:
+                    appns: {{ .Values.target.ns }}
+                    applabel: app={{ .Values.target.app }}
+                    appkind: deployment
+                  experiments:
+                    - name: pod-delete
+                      spec:
+                        components:
+                          env:
+                            - name: TOTAL_CHAOS_DURATION
+                              value: "{{ .Values.duration }}"
+      container:
+        image: litmuschaos/k8s:latest
+`
+
+func TestLoadValues(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	prod := filepath.Join(dir, "prod.yaml")
+	os.WriteFile(base, []byte("target:\n  ns: default\n  app: cart\nduration: 30\n"), 0600)
+	os.WriteFile(prod, []byte("target:\n  ns: prod\n"), 0600)
+
+	values, err := LoadValues([]string{base, prod}, []string{"target.ns=checkout", "probe.url=http://cart:8080/health?a=b"})
+	if err != nil {
+		t.Fatalf("LoadValues() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"target":   map[string]interface{}{"ns": "checkout", "app": "cart"},
+		"duration": float64(30),
+		"probe":    map[string]interface{}{"url": "http://cart:8080/health?a=b"},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("LoadValues() = %v, want %v", values, want)
+	}
+
+	// Overrides are typed like YAML scalars
+	values, err = LoadValues(nil, []string{"suspend=true", "replicas=3", `tag="1.10"`, "selector=a: b", "empty=", "none=null"})
+	if err != nil {
+		t.Fatalf("LoadValues() error = %v", err)
+	}
+	want = map[string]interface{}{
+		"suspend":  true,
+		"replicas": float64(3),
+		"tag":      "1.10",
+		"selector": "a: b",
+		"empty":    "",
+		"none":     "null",
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("LoadValues() = %v, want %v", values, want)
+	}
+
+	for _, set := range []string{"target", "=x", "duration.seconds=10"} {
+		if _, err := LoadValues([]string{base}, []string{set}); err == nil {
+			t.Errorf("LoadValues() with --set %s succeeded, want an error", set)
+		}
+	}
+}
+
+func TestRenderManifest(t *testing.T) {
+	values := map[string]interface{}{
+		"target":   map[string]interface{}{"ns": "checkout", "app": "cart"},
+		"duration": float64(60),
+	}
+
+	rendered, err := RenderManifest([]byte(testTemplate), values)
+	if err != nil {
+		t.Fatalf("RenderManifest() error = %v", err)
+	}
+	for _, want := range []string{"name: pod-delete-cart", "appns: checkout", "applabel: app=cart", `value: "60"`, `"{{workflow.parameters.adminModeNamespace}}"`, `"{{workflow.uid}}"`} {
+		if !strings.Contains(string(rendered), want) {
+			t.Errorf("Rendered manifest doesn't contain %s:\n%s", want, rendered)
+		}
+	}
+
+	// The rendered manifest is a valid experiment
+	var request model.SaveChaosExperimentRequest
+	if err := ParseExperimentManifestData(rendered, &request); err != nil {
+		t.Fatalf("ParseExperimentManifestData() of the rendered manifest error = %v", err)
+	}
+	if request.Name != "pod-delete-cart" {
+		t.Errorf("Rendered experiment name = %q, want pod-delete-cart", request.Name)
+	}
+
+	// Values are quoted, in the workflow and in the raw artifact
+	special := "it's \"a: b\" # c\nline 2"
+	rendered, err = RenderManifest([]byte(testTemplate), map[string]interface{}{
+		"target":   map[string]interface{}{"ns": special, "app": special},
+		"duration": "30",
+	})
+	if err != nil {
+		t.Fatalf("RenderManifest() with special characters error = %v", err)
+	}
+	var workflow struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Spec struct {
+			Templates []struct {
+				Inputs struct {
+					Artifacts []struct {
+						Raw struct {
+							Data string `json:"data"`
+						} `json:"raw"`
+					} `json:"artifacts"`
+				} `json:"inputs"`
+			} `json:"templates"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal(rendered, &workflow); err != nil {
+		t.Fatalf("Rendered manifest is invalid: %v\n%s", err, rendered)
+	}
+	if want := "pod-delete-" + special; workflow.Metadata.Name != want {
+		t.Errorf("Rendered name = %q, want %q", workflow.Metadata.Name, want)
+	}
+	var engine struct {
+		Spec struct {
+			Appinfo map[string]string `json:"appinfo"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(workflow.Spec.Templates[0].Inputs.Artifacts[0].Raw.Data), &engine); err != nil {
+		t.Fatalf("Rendered ChaosEngine is invalid: %v\n%s", err, rendered)
+	}
+	if engine.Spec.Appinfo["appns"] != special || engine.Spec.Appinfo["applabel"] != "app="+special {
+		t.Errorf("Rendered appinfo = %v, want appns %q", engine.Spec.Appinfo, special)
+	}
+
+	// Typed --set overrides render unquoted where the placeholder is
+	values, err = LoadValues(nil, []string{"suspend=true", "replicas=3"})
+	if err != nil {
+		t.Fatalf("LoadValues() error = %v", err)
+	}
+	rendered, err = RenderManifest([]byte("spec:\n  suspend: {{ .Values.suspend }}\n  parallelism: {{ .Values.replicas }}\n  label: \"{{ .Values.replicas }}\"\n"), values)
+	if err != nil {
+		t.Fatalf("RenderManifest() with typed values error = %v", err)
+	}
+	if want := "spec:\n  suspend: true\n  parallelism: 3\n  label: \"3\"\n"; string(rendered) != want {
+		t.Errorf("RenderManifest() with typed values = %q, want %q", rendered, want)
+	}
+
+	// Every unresolved variable is reported
+	_, err = RenderManifest([]byte(testTemplate), map[string]interface{}{"target": map[string]interface{}{"app": "cart"}})
+	if err == nil {
+		t.Fatal("RenderManifest() with missing values succeeded, want an error")
+	}
+	for _, want := range []string{"line 32: {{ .Values.target.ns }}", "line 41: {{ .Values.duration }}"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("RenderManifest() error = %v, want it to report %s", err, want)
+		}
+	}
+
+	// Lines are those of the template, whatever the values substituted before
+	_, err = RenderManifest([]byte(testTemplate), map[string]interface{}{"target": map[string]interface{}{"app": "a\nb\nc"}})
+	if err == nil {
+		t.Fatal("RenderManifest() with missing values succeeded, want an error")
+	}
+	for _, want := range []string{"line 32: {{ .Values.target.ns }}", "line 41: {{ .Values.duration }}"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("RenderManifest() error = %v, want it to report %s", err, want)
+		}
+	}
+}
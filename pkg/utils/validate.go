@@ -0,0 +1,303 @@
+/*
+Copyright © 2021 The LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	chaosTypes "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// MinWeight and MaxWeight bound the weight label of the templates
+	// injecting a fault, as in ChaosCenter
+	MinWeight = 0
+	MaxWeight = 10
+	// probeRefAnnotation references the probes of ChaosCenter run by a fault
+	probeRefAnnotation = "probeRef"
+)
+
+var (
+	faultName  = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	appKinds   = map[string]bool{"deployment": true, "statefulset": true, "daemonset": true, "deploymentconfig": true, "rollout": true}
+	probeTypes = map[string]bool{"httpProbe": true, "cmdProbe": true, "k8sProbe": true, "promProbe": true, "sloProbe": true}
+)
+
+// ManifestProblem is a problem found in an experiment manifest, at the path
+// of a template of the workflow
+type ManifestProblem struct {
+	// Template is the name of the template, empty for the workflow itself
+	Template string
+	Path     string
+	Message  string
+}
+
+func (p ManifestProblem) String() string {
+	location := p.Path
+	if p.Template != "" {
+		location = "template " + p.Template + ": " + location
+	}
+	return location + ": " + p.Message
+}
+
+// ValidateExperimentManifest checks a Workflow or CronWorkflow manifest
+// without ChaosCenter and returns every problem found: the workflow itself,
+// its schedule, and each ChaosEngine of its raw artifacts, with its fault
+// names, experiments, appinfo, weight and probes
+func ValidateExperimentManifest(body []byte) []ManifestProblem {
+	var problems []ManifestProblem
+	report := func(template string, path string, format string, args ...interface{}) {
+		problems = append(problems, ManifestProblem{Template: template, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var meta struct {
+		Kind string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(body, &meta); err != nil {
+		report("", "", "invalid YAML: %v", err)
+		return problems
+	}
+
+	var name, generateName string
+	var spec v1alpha1.WorkflowSpec
+	specPath := "spec"
+	switch meta.Kind {
+	case "Workflow":
+		var workflow v1alpha1.Workflow
+		if err := UnmarshalObject(body, &workflow); err != nil {
+			report("", "", "invalid Workflow: %v", err)
+			return problems
+		}
+		name, generateName, spec = workflow.Name, workflow.GenerateName, workflow.Spec
+	case "CronWorkflow":
+		var cronWorkflow v1alpha1.CronWorkflow
+		if err := UnmarshalObject(body, &cronWorkflow); err != nil {
+			report("", "", "invalid CronWorkflow: %v", err)
+			return problems
+		}
+		name, generateName, spec = cronWorkflow.Name, cronWorkflow.GenerateName, cronWorkflow.Spec.WorkflowSpec
+		specPath = "spec.workflowSpec"
+
+		schedules := cronWorkflow.Spec.Schedules
+		schedulesPath := "spec.schedules"
+		if cronWorkflow.Spec.Schedule != "" {
+			schedules = []string{cronWorkflow.Spec.Schedule}
+			schedulesPath = "spec.schedule"
+		}
+		if len(schedules) == 0 {
+			report("", "spec.schedule", "a CronWorkflow needs a schedule")
+		}
+		for i, schedule := range schedules {
+			path := schedulesPath
+			if schedulesPath == "spec.schedules" {
+				path += "[" + strconv.Itoa(i) + "]"
+			}
+			if err := ValidateCronSyntax(schedule); err != nil {
+				report("", path, "invalid cron syntax %q: %v", schedule, err)
+			}
+		}
+		if cronWorkflow.Spec.Timezone != "" {
+			if _, err := time.LoadLocation(cronWorkflow.Spec.Timezone); err != nil {
+				report("", "spec.timezone", "unknown timezone %q", cronWorkflow.Spec.Timezone)
+			}
+		}
+	default:
+		report("", "kind", "expected a Workflow or CronWorkflow, found %q", meta.Kind)
+		return problems
+	}
+
+	if name == "" && generateName == "" {
+		report("", "metadata", "no name or generateName")
+	}
+	if len(spec.Templates) == 0 {
+		report("", specPath+".templates", "no templates")
+	}
+
+	templates := map[string]bool{}
+	for _, template := range spec.Templates {
+		templates[template.Name] = true
+	}
+	if spec.Entrypoint == "" {
+		report("", specPath+".entrypoint", "no entrypoint")
+	} else if !templates[spec.Entrypoint] {
+		report("", specPath+".entrypoint", "template %q is not defined", spec.Entrypoint)
+	}
+
+	// Faults installed by the workflow, when it installs them
+	installed := map[string]bool{}
+	for _, template := range spec.Templates {
+		for _, artifact := range template.Inputs.Artifacts {
+			if artifact.Raw == nil {
+				continue
+			}
+			for _, document := range SplitManifests([]byte(stripArgoExpressions(artifact.Raw.Data))) {
+				var experiment chaosTypes.ChaosExperiment
+				if err := yaml.Unmarshal(document, &experiment); err == nil && experiment.Kind == "ChaosExperiment" {
+					installed[experiment.Name] = true
+				}
+			}
+		}
+	}
+
+	for i, template := range spec.Templates {
+		templatePath := specPath + ".templates[" + strconv.Itoa(i) + "]"
+		for _, step := range template.Steps {
+			for _, parallel := range step.Steps {
+				if parallel.Template != "" && !templates[parallel.Template] {
+					report(template.Name, templatePath+".steps", "step %q runs template %q, which is not defined", parallel.Name, parallel.Template)
+				}
+			}
+		}
+
+		for j, artifact := range template.Inputs.Artifacts {
+			if artifact.Raw == nil {
+				continue
+			}
+			path := templatePath + ".inputs.artifacts[" + strconv.Itoa(j) + "].raw.data"
+			engine, isEngine, err := parseChaosEngine(artifact.Raw.Data)
+			if err != nil {
+				report(template.Name, path, "invalid raw artifact: %v", err)
+				continue
+			}
+			if !isEngine {
+				continue
+			}
+			problems = append(problems, validateChaosEngine(template.Name, path, engine, installed)...)
+		}
+
+		if w, ok := template.Metadata.Labels["weight"]; ok {
+			weight, err := strconv.Atoi(w)
+			if err != nil || weight < MinWeight || weight > MaxWeight {
+				report(template.Name, templatePath+".metadata.labels.weight", "weight %q must be an integer between %d and %d", w, MinWeight, MaxWeight)
+			}
+		}
+	}
+
+	return problems
+}
+
+// stripArgoExpressions removes the braces of Argo expressions, such as
+// {{workflow.parameters.adminModeNamespace}}, so that raw artifacts parse
+func stripArgoExpressions(data string) string {
+	data = strings.ReplaceAll(data, "{{", "")
+	return strings.ReplaceAll(data, "}}", "")
+}
+
+func parseChaosEngine(data string) (chaosTypes.ChaosEngine, bool, error) {
+	var engine chaosTypes.ChaosEngine
+	if err := yaml.Unmarshal([]byte(stripArgoExpressions(data)), &engine); err != nil {
+		// Raw artifacts holding several documents, such as the faults to
+		// install, are not ChaosEngines
+		if len(SplitManifests([]byte(data))) > 1 {
+			return engine, false, nil
+		}
+		return engine, false, err
+	}
+
+	return engine, strings.EqualFold(engine.Kind, "ChaosEngine"), nil
+}
+
+func validateChaosEngine(template string, path string, engine chaosTypes.ChaosEngine, installed map[string]bool) []ManifestProblem {
+	var problems []ManifestProblem
+	report := func(field string, format string, args ...interface{}) {
+		problems = append(problems, ManifestProblem{Template: template, Path: path + ": " + field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if engine.GenerateName == "" && engine.Name == "" {
+		report("metadata", "no generateName to name the fault")
+	} else if engine.GenerateName == "" {
+		report("metadata.generateName", "no generateName, ChaosCenter names the fault of the ChaosEngine by its generateName")
+	}
+
+	appinfo := engine.Spec.Appinfo
+	if appinfo.AppKind != "" && !appKinds[appinfo.AppKind] {
+		report("spec.appinfo.appkind", "unknown kind %q", appinfo.AppKind)
+	}
+	if appinfo.Applabel != "" && appinfo.Appns == "" {
+		report("spec.appinfo.appns", "appns is required when applabel is set")
+	}
+	if appinfo.Applabel != "" && !strings.Contains(appinfo.Applabel, "=") && !strings.Contains(appinfo.Applabel, " in ") {
+		report("spec.appinfo.applabel", "invalid label selector %q, expected key=value", appinfo.Applabel)
+	}
+
+	if len(engine.Spec.Experiments) == 0 {
+		report("spec.experiments", "no experiments")
+	}
+	probeNames := map[string]bool{}
+	for i, experiment := range engine.Spec.Experiments {
+		experimentPath := "spec.experiments[" + strconv.Itoa(i) + "]"
+		switch {
+		case experiment.Name == "":
+			report(experimentPath+".name", "empty fault name")
+		case !faultName.MatchString(experiment.Name):
+			report(experimentPath+".name", "invalid fault name %q", experiment.Name)
+		case len(installed) > 0 && !installed[experiment.Name]:
+			report(experimentPath+".name", "fault %q is not installed by the workflow", experiment.Name)
+		}
+
+		for j, probe := range experiment.Spec.Probe {
+			probePath := experimentPath + ".spec.probe[" + strconv.Itoa(j) + "]"
+			if probe.Name == "" {
+				report(probePath+".name", "empty probe name")
+			} else if probeNames[probe.Name] {
+				report(probePath+".name", "duplicate probe %q", probe.Name)
+			}
+			probeNames[probe.Name] = true
+			if !probeTypes[probe.Type] {
+				report(probePath+".type", "unknown probe type %q", probe.Type)
+			}
+			if !isProbeMode(probe.Mode) {
+				report(probePath+".mode", "unknown probe mode %q", probe.Mode)
+			}
+		}
+	}
+
+	if refs, ok := engine.Annotations[probeRefAnnotation]; ok {
+		var probeRefs []struct {
+			Name string `json:"name"`
+			Mode string `json:"mode"`
+		}
+		if err := json.Unmarshal([]byte(refs), &probeRefs); err != nil {
+			report("metadata.annotations.probeRef", "invalid probe references: %v", err)
+		}
+		for i, ref := range probeRefs {
+			refPath := "metadata.annotations.probeRef[" + strconv.Itoa(i) + "]"
+			if ref.Name == "" {
+				report(refPath+".name", "empty probe name")
+			} else if probeNames[ref.Name] {
+				report(refPath+".name", "duplicate probe %q", ref.Name)
+			}
+			probeNames[ref.Name] = true
+			if !isProbeMode(ref.Mode) {
+				report(refPath+".mode", "unknown probe mode %q", ref.Mode)
+			}
+		}
+	}
+
+	return problems
+}
+
+func isProbeMode(mode string) bool {
+	return model.Mode(mode).IsValid()
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testInvalidManifest = `apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: broken
+spec:
+  schedule: "0 */2 * *"
+  timezone: Mars/Olympus
+  workflowSpec:
+    entrypoint: missing
+    templates:
+      - name: install-chaos-faults
+        inputs:
+          artifacts:
+            - name: pod-delete
+              path: /tmp/pod-delete.yaml
+              raw:
+                data: |
+                  apiVersion: litmuschaos.io/v1alpha1
+                  kind: ChaosExperiment
+                  metadata:
+                    name: pod-delete
+      - name: pod-delete
+        metadata:
+          labels:
+            weight: "12"
+        inputs:
+          artifacts:
+            - name: pod-delete
+              path: /tmp/chaosengine.yaml
+              raw:
+                data: |
+                  apiVersion: litmuschaos.io/v1alpha1
+                  kind: ChaosEngine
+                  metadata:
+                    name: pod-delete
+                    annotations:
+                      probeRef: '[{"name":"check-frontend","mode":"Sometimes"}]'
+                  spec:
+                    appinfo:
+                      applabel: app=cart
+                      appkind: pod
+                    experiments:
+                      - name: pod-kill
+                        spec:
+                          probe:
+                            - name: check-frontend
+                              type: httpProbe
+                              mode: SOT
+      - name: empty-engine
+        inputs:
+          artifacts:
+            - name: empty
+              path: /tmp/chaosengine.yaml
+              raw:
+                data: |
+                  apiVersion: litmuschaos.io/v1alpha1
+                  kind: ChaosEngine
+                  metadata:
+                    generateName: empty
+                  spec:
+                    experiments: []
+      - name: invalid-engine
+        inputs:
+          artifacts:
+            - name: invalid
+              path: /tmp/chaosengine.yaml
+              raw:
+                data: "kind: ChaosEngine\nspec: [\n"
+`
+
+func TestValidateExperimentManifest(t *testing.T) {
+	rendered, err := RenderManifest([]byte(testTemplate), map[string]interface{}{
+		"target":   map[string]interface{}{"ns": "checkout", "app": "cart"},
+		"duration": 60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if problems := ValidateExperimentManifest(rendered); len(problems) != 0 {
+		t.Errorf("ValidateExperimentManifest() of a valid manifest = %v, want no problems", problems)
+	}
+
+	var got []string
+	for _, problem := range ValidateExperimentManifest([]byte(testInvalidManifest)) {
+		got = append(got, problem.String())
+	}
+	sort.Strings(got)
+	want := []string{
+		"spec.schedule: invalid cron syntax",
+		"spec.timezone: unknown timezone",
+		"spec.workflowSpec.entrypoint: template \"missing\" is not defined",
+		"template empty-engine: spec.workflowSpec.templates[2].inputs.artifacts[0].raw.data: spec.experiments: no experiments",
+		"template invalid-engine: spec.workflowSpec.templates[3].inputs.artifacts[0].raw.data: invalid raw artifact",
+		"template pod-delete: spec.workflowSpec.templates[1].inputs.artifacts[0].raw.data: metadata.annotations.probeRef[0].mode: unknown probe mode \"Sometimes\"",
+		"template pod-delete: spec.workflowSpec.templates[1].inputs.artifacts[0].raw.data: metadata.annotations.probeRef[0].name: duplicate probe \"check-frontend\"",
+		"template pod-delete: spec.workflowSpec.templates[1].inputs.artifacts[0].raw.data: metadata.generateName: no generateName",
+		"template pod-delete: spec.workflowSpec.templates[1].inputs.artifacts[0].raw.data: spec.appinfo.appkind: unknown kind \"pod\"",
+		"template pod-delete: spec.workflowSpec.templates[1].inputs.artifacts[0].raw.data: spec.appinfo.appns: appns is required",
+		"template pod-delete: spec.workflowSpec.templates[1].inputs.artifacts[0].raw.data: spec.experiments[0].name: fault \"pod-kill\" is not installed by the workflow",
+		"template pod-delete: spec.workflowSpec.templates[1].metadata.labels.weight: weight \"12\" must be an integer between 0 and 10",
+	}
+	if len(got) != len(want) {
+		t.Errorf("ValidateExperimentManifest() found %d problems, want %d:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for _, problem := range want {
+		found := false
+		for _, g := range got {
+			if strings.HasPrefix(g, problem) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("ValidateExperimentManifest() didn't report %s, got:\n%s", problem, strings.Join(got, "\n"))
+		}
+	}
+
+	if problems := ValidateExperimentManifest([]byte("kind: Deployment\n")); len(problems) != 1 || problems[0].Path != "kind" {
+		t.Errorf("ValidateExperimentManifest() of a Deployment = %v, want a problem with the kind", problems)
+	}
+}